@@ -0,0 +1,170 @@
+package cpick
+
+import (
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// colorCache holds the parsed colors of a color file, along with the path,
+// modification time, and size the file had when it was parsed
+type colorCache struct {
+	Path    string
+	ModTime time.Time
+	Size    int64
+	Data    jsonData
+}
+
+// The error from the last time the colors of a color file couldn't be
+// cached, which is shown by the picker that read the file
+var colorCacheErr error
+var colorCacheErrMutex sync.Mutex
+
+// Cache functions --------------------------------------------------------
+
+// Read and parse a color file. If cache is true, the parsed colors are kept
+// in ~/.config/cpick/cache until the file changes.
+func loadColorFile(path string, cache bool) (jsonData, error) {
+	var cachePath string
+	if cache {
+		cachePath, _ = colorCachePath(path)
+	}
+	if cachePath != "" {
+		if data, ok := readColorCache(cachePath, path); ok {
+			return data, nil
+		}
+	}
+
+	var data jsonData
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return data, err
+	}
+
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return data, fmt.Errorf("%v: %w", path, err)
+	}
+	computeColors(&data)
+
+	// The colors are still shown if the cache can't be written
+	if cachePath != "" {
+		if err := writeColorCache(cachePath, path, data); err != nil {
+			colorCacheErrMutex.Lock()
+			colorCacheErr = err
+			colorCacheErrMutex.Unlock()
+		}
+	}
+
+	return data, nil
+}
+
+// Get the path of the cache of a color file. Each color file has its own
+// cache, named after the file and a hash of its absolute path.
+func colorCachePath(path string) (string, error) {
+	dir, err := configPath("cache")
+	if err != nil {
+		return "", err
+	}
+
+	abs := absPath(path)
+	hash := fnv.New64a()
+	hash.Write([]byte(abs))
+
+	name := strings.TrimSuffix(filepath.Base(abs), filepath.Ext(abs))
+	return filepath.Join(dir, fmt.Sprintf("%v-%016x.cache", name, hash.Sum64())), nil
+}
+
+// Read the cached colors of a color file. The cache is only used if it was
+// made from the same file and the file hasn't changed since.
+func readColorCache(cachePath string, path string) (jsonData, bool) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return jsonData{}, false
+	}
+
+	f, err := os.Open(cachePath)
+	if err != nil {
+		return jsonData{}, false
+	}
+	defer f.Close()
+
+	var cache colorCache
+	if err := gob.NewDecoder(f).Decode(&cache); err != nil {
+		return jsonData{}, false
+	}
+
+	if cache.Path != absPath(path) || !cache.ModTime.Equal(info.ModTime()) || cache.Size != info.Size() {
+		return jsonData{}, false
+	}
+
+	return cache.Data, true
+}
+
+// Write the parsed colors of a color file to the cache. The cache is written
+// to a temporary file first, so that a cache that is being written is never
+// read.
+func writeColorCache(cachePath string, path string, data jsonData) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(cachePath), 0755); err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(cachePath), filepath.Base(cachePath)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	cache := colorCache{Path: absPath(path), ModTime: info.ModTime(), Size: info.Size(), Data: data}
+	if err := gob.NewEncoder(f).Encode(cache); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), cachePath)
+}
+
+// Get and forget the error from the last time the colors couldn't be cached
+func takeColorCacheErr() error {
+	colorCacheErrMutex.Lock()
+	defer colorCacheErrMutex.Unlock()
+
+	err := colorCacheErr
+	colorCacheErr = nil
+	return err
+}
+
+// Show the error from the last time the colors of the picker couldn't be
+// cached
+func (p *Picker) warnColorCache() {
+	if p.colorCacheErr == nil {
+		return
+	}
+
+	p.setStatus(fmt.Sprintf("Could not cache the colors: %v", p.colorCacheErr), true)
+}
+
+// Helper functions -------------------------------------------------------
+
+// Get the absolute path of a file, so that ./colors.json is told apart in
+// different directories
+func absPath(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		return abs
+	}
+	return path
+}
@@ -0,0 +1,167 @@
+package cpick
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	color "github.com/ethanbaker/colors"
+)
+
+// ErrNoClipboard is returned when no clipboard program can be found
+var ErrNoClipboard = errors.New("no clipboard program found")
+
+// clipboardCommands returns the commands that can be used to write to the
+// clipboard on the current system, in order of preference
+func clipboardCommands() [][]string {
+	switch runtime.GOOS {
+	case "darwin":
+		return [][]string{{"pbcopy"}}
+	case "windows":
+		return [][]string{{"clip.exe"}}
+	}
+
+	var commands [][]string
+	if os.Getenv("WAYLAND_DISPLAY") != "" {
+		commands = append(commands, []string{"wl-copy"})
+	}
+	commands = append(commands,
+		[]string{"xclip", "-selection", "clipboard"},
+		[]string{"xsel", "--clipboard", "--input"},
+		[]string{"termux-clipboard-set"},
+	)
+
+	return commands
+}
+
+// CopyToClipboard copies the given text to the system clipboard. If no
+// clipboard program is available, ErrNoClipboard is returned.
+func CopyToClipboard(text string) error {
+	for _, command := range clipboardCommands() {
+		path, err := exec.LookPath(command[0])
+		if err != nil {
+			continue
+		}
+
+		cmd := exec.Command(path, command[1:]...)
+		cmd.Stdin = strings.NewReader(text)
+		return cmd.Run()
+	}
+
+	return ErrNoClipboard
+}
+
+// Format a color as the given type to be copied to the clipboard
+func formatColor(c ColorValues, colorType string) string {
+	switch colorType {
+	case "rgb":
+		return fmt.Sprintf("%v;%v;%v", c.RGB.R, c.RGB.G, c.RGB.B)
+	case "hsv":
+		return fmt.Sprintf("%v;%v;%v", c.HSV.H, c.HSV.S, c.HSV.V)
+	case "hsl":
+		return fmt.Sprintf("%v;%v;%v", c.HSL.H, c.HSL.S, c.HSL.L)
+	case "hwb":
+		return fmt.Sprintf("%v;%v;%v", c.HWB.H, c.HWB.W, c.HWB.B)
+	case "cmyk":
+		return fmt.Sprintf("%v;%v;%v;%v", c.CMYK.C, c.CMYK.M, c.CMYK.Y, c.CMYK.K)
+	case "decimal":
+		return fmt.Sprintf("%v", c.Decimal)
+	case "ansi":
+		return string(c.Ansi)
+	case "escape":
+		return "\\033[" + strings.SplitN(string(c.Ansi), "[", 2)[1]
+	case "name":
+		return c.Name
+	}
+
+	return fmt.Sprintf("#%v", c.Hex)
+}
+
+// Copy the color highlighted on the focused table to the clipboard and show
+// whether it was copied
+func (p *Picker) copyCurrentColor() error {
+	hsv, ok := p.getCurrentColor()
+	if !ok {
+		return nil
+	}
+
+	c := newColorValues(hsv, p.getColorName(hsv, hsv), p.alpha)
+	text := formatColor(c, p.clipboardType)
+	if err := CopyToClipboard(text); err != nil {
+		p.setStatus(fmt.Sprintf("Could not copy %v: %v", text, err), true)
+		return err
+	}
+
+	p.setStatus("Copied "+text, false)
+	return nil
+}
+
+// Copy the truecolor escape sequence of the color highlighted on the focused
+// table to the clipboard and show whether it was copied
+func (p *Picker) copyCurrentEscape() error {
+	hsv, ok := p.getCurrentColor()
+	if !ok {
+		return nil
+	}
+
+	text := formatColor(newColorValues(hsv, "", p.alpha), "escape")
+	if err := CopyToClipboard(text); err != nil {
+		p.setStatus(fmt.Sprintf("Could not copy %v: %v", text, err), true)
+		return err
+	}
+
+	p.setStatus("Copied "+text, false)
+	return nil
+}
+
+// Get the color highlighted on the focused table
+func (p *Picker) getCurrentColor() (color.HSV, bool) {
+	switch {
+	case p.svTable.HasFocus():
+		row, col := p.svTable.GetSelection()
+		return p.svColor(col, p.svValue(row*2+p.svHalf)), true
+
+	case p.hTable.HasFocus():
+		_, col := p.hTable.GetSelection()
+		return color.HSV{H: col * p.hueStep, S: 100, V: 100}, true
+
+	case p.colorPages.HasFocus():
+		row, col := p.colorInfo[p.colorPageIndex].table.GetSelection()
+		text := p.colorInfo[p.colorPageIndex].table.GetCell(row, col).Text
+		raw := strings.Split(string(text), "#")
+		if len(raw) < 2 {
+			return color.HSV{}, false
+		}
+		return color.HextoHSV(color.Hex(strings.TrimSpace(raw[1]))), true
+
+	case p.sliderForm.HasFocus():
+		return p.sliderColor(), true
+
+	case p.cmykForm.HasFocus():
+		return color.CMYKtoHSV(p.cmykColor()), true
+
+	case p.favoritesTable.HasFocus():
+		row, _ := p.favoritesTable.GetSelection()
+		if row >= len(p.favorites) {
+			return color.HSV{}, false
+		}
+		return color.HextoHSV(color.Hex(p.favorites[row])), true
+
+	case p.gradientTable.HasFocus():
+		row, _ := p.gradientTable.GetSelection()
+		c, ok := p.gradientTable.GetCell(row, 0).GetReference().(ColorValues)
+		return c.HSV, ok
+
+	case p.namedList.HasFocus():
+		index := p.namedList.GetCurrentItemIndex()
+		if index < 0 || index >= len(p.namedColors) {
+			return color.HSV{}, false
+		}
+		return color.HextoHSV(color.Hex(strings.TrimPrefix(p.namedColors[index].VALUE, "#"))), true
+	}
+
+	return color.HSV{}, false
+}
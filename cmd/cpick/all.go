@@ -0,0 +1,44 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethanbaker/cpick"
+	"github.com/rwxrob/cmdtab"
+)
+
+// The types printed by all, in the order they are printed
+var allTypes = []string{"rgb", "hsv", "hsl", "hwb", "cmyk", "hex", "decimal", "ansi", "name"}
+
+func init() {
+	x := cmdtab.New("all")
+
+	x.Usage = ""
+	x.Summary = "Return every basic type of the color on labeled lines"
+
+	x.Description = `
+	The *all* subcommand is used to return the rgb, hsv, hsl, hwb,
+	cmyk, hex, decimal, ansi, and name types for a color that is
+	selected when cpick is running, each on its own line after the
+	name of the type. The -delimiter and -uppercase options are used
+	by the types that support them.`
+
+	formats["all"] = func(c cpick.ColorValues, args []string) (string, error) {
+		var b strings.Builder
+		for _, name := range allTypes {
+			s, err := formats[name](c, nil)
+			if err != nil {
+				return "", err
+			}
+
+			fmt.Fprintf(&b, "%-8v %v\n", name+":", strings.TrimSuffix(s, "\n"))
+		}
+
+		return b.String(), nil
+	}
+
+	x.Method = func(args []string) error {
+		return run("all", args)
+	}
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/ethanbaker/cpick"
+	"github.com/rwxrob/cmdtab"
+)
+
+func init() {
+	x := cmdtab.New("android")
+
+	x.Usage = "<name>"
+	x.Summary = "Return an Android color resource with the color in ARGB format"
+
+	x.Description = `
+	The *android* subcommand is used to return an Android color
+	resource for a color that is selected when cpick is running. The
+	color is written as an uppercase ARGB hex value, where the alpha
+	can be changed on the saturation-value table. A specific name can
+	be specified, which will be used as the name of the resource. If
+	no name is specified, the name is "custom".`
+
+	formats["android"] = func(c cpick.ColorValues, args []string) (string, error) {
+		name := "custom"
+		if len(args) > 0 {
+			name = args[0]
+		}
+
+		a := int(math.Round(c.Alpha * 255))
+		return fmt.Sprintf("<color name=\"%v\">#%02X%02X%02X%02X</color>\n", name, a, c.RGB.R, c.RGB.G, c.RGB.B), nil
+	}
+
+	x.Method = func(args []string) error {
+		return run("android", args)
+	}
+}
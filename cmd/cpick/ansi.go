@@ -18,14 +18,11 @@ func init() {
 	code for a color that is selected when cpick is running. This will 
 	return an actual color on a terminal.`
 
-	x.Method = func(args []string) error {
-		c, err := cpick.Start(false)
-		if err != nil {
-			return err
-		}
-
-		fmt.Println(c.Ansi)
+	formats["ansi"] = func(c cpick.ColorValues, args []string) (string, error) {
+		return fmt.Sprintln(c.Ansi), nil
+	}
 
-		return nil
+	x.Method = func(args []string) error {
+		return run("ansi", args)
 	}
 }
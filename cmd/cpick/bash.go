@@ -20,19 +20,16 @@ func init() {
 	variable. If no name is specified, the variable name is
 	"custom".`
 
-	x.Method = func(args []string) error {
-		c, err := cpick.Start(false)
-		if err != nil {
-			return err
-		}
-
+	formats["bash"] = func(c cpick.ColorValues, args []string) (string, error) {
 		name := "custom"
 		if len(args) > 0 {
 			name = args[0]
 		}
 
-		fmt.Printf("readonly -r %v=$'\\033[38;2;%v;%v;%vm'\n", name, c.RGB.R, c.RGB.G, c.RGB.B)
+		return fmt.Sprintf("readonly -r %v=$'\\033[38;2;%v;%v;%vm'\n", name, c.RGB.R, c.RGB.G, c.RGB.B), nil
+	}
 
-		return nil
+	x.Method = func(args []string) error {
+		return run("bash", args)
 	}
 }
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/ethanbaker/cpick"
+	"github.com/rwxrob/cmdtab"
+)
+
+func init() {
+	x := cmdtab.New("batch")
+
+	x.Usage = "<file> [<type>]"
+	x.Summary = "Convert every color in a file to a different type without starting cpick"
+
+	x.Description = `
+	The *batch* subcommand is used to convert a file of colors, one
+	color on each line, into one of the other output types without
+	starting the color picker. The colors can be given in any of the
+	formats accepted by convert, and each converted color is printed
+	on its own line. Empty lines are skipped. A line that can't be
+	converted is reported on stderr with its line number, and the
+	rest of the file is still converted. If the file is "-", the
+	colors are read from standard input. Any arguments after the type
+	are passed to the type. If no type is specified, the type in
+	CPICK_DEFAULT_TYPE is used, or "ansi" if it isn't set.`
+
+	x.Method = func(args []string) error {
+		args, err := parseArgs(args)
+		if err != nil {
+			return err
+		}
+		if len(args) == 0 {
+			return x.UsageError()
+		}
+
+		path := args[0]
+
+		name := cpickCommand.Default
+		if len(args) > 1 {
+			name = args[1]
+			args = args[2:]
+		} else {
+			args = nil
+		}
+
+		var in io.Reader = os.Stdin
+		if path != "-" {
+			f, err := os.Open(path)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			in = f
+		}
+
+		closeOutput, err := openOutput()
+		if err != nil {
+			return err
+		}
+		defer closeOutput()
+
+		failed := 0
+		scanner := bufio.NewScanner(in)
+		for line := 1; scanner.Scan(); line++ {
+			text := strings.TrimSpace(scanner.Text())
+			if text == "" {
+				continue
+			}
+
+			c, err := cpick.Convert(text)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "%v:%v: %v\n", path, line, err)
+				failed++
+				continue
+			}
+
+			if err := outputLine(name, c, args); err != nil {
+				return err
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			return err
+		}
+
+		if failed > 0 {
+			return fmt.Errorf("%v of the colors could not be converted", failed)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rwxrob/cmdtab"
+)
+
+func Test_Batch(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("HOME", dir)
+
+	input := filepath.Join(dir, "colors.txt")
+	if err := os.WriteFile(input, []byte("#ff0000\n\nnot a color\n#0000ff\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Every color is on its own line, even in types without a newline
+	var types = map[string]string{"hex": "#ff0000\n#0000ff\n", "name": "red\nblue\n", "escape": "\\033[38;2;255;0;0m\n\\033[38;2;0;0;255m\n"}
+	for name, expected := range types {
+		output := filepath.Join(dir, name+".txt")
+		if err := cmdtab.Call("batch", []string{input, name, "-o", output}); err == nil {
+			t.Errorf("batch is not properly reporting the line that can't be converted!\n")
+		}
+
+		raw, err := os.ReadFile(output)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(raw) != expected {
+			t.Errorf("batch is not properly printing the %v colors on their own lines!\nOutput: %q\n", name, raw)
+		}
+	}
+}
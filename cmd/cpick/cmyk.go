@@ -16,16 +16,15 @@ func init() {
 	x.Description = `
 	The *cmyk* subcommand is used to return the CMYK values (between 0
 	and 100, inclusive) for a color that is selected when cpick is 
-	running. The CMYK values are separated by semi-colons.`
+	running. The CMYK values are separated by semi-colons, or by
+	the delimiter given with -delimiter.`
 
-	x.Method = func(args []string) error {
-		c, err := cpick.Start(false)
-		if err != nil {
-			return err
-		}
-
-		fmt.Printf("%v;%v;%v;%v\n", c.CMYK.C, c.CMYK.M, c.CMYK.Y, c.CMYK.K)
+	formats["cmyk"] = func(c cpick.ColorValues, args []string) (string, error) {
+		d := delimiter(";")
+		return fmt.Sprintf("%v%v%v%v%v%v%v\n", c.CMYK.C, d, c.CMYK.M, d, c.CMYK.Y, d, c.CMYK.K), nil
+	}
 
-		return nil
+	x.Method = func(args []string) error {
+		return run("cmyk", args)
 	}
 }
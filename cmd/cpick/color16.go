@@ -0,0 +1,30 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/ethanbaker/cpick"
+	"github.com/rwxrob/cmdtab"
+)
+
+func init() {
+	x := cmdtab.New("color16")
+
+	x.Usage = ""
+	x.Summary = "Return the SGR code of one of the 16 standard terminal colors"
+
+	x.Description = `
+	The *color16* subcommand is used to return the SGR code (30-37 or
+	90-97) of a color that is selected when cpick is running, which
+	can be used in prompt colors (EX: \033[31m). If the color was not
+	picked from the standard terminal colors (with x), the code of the
+	closest standard color is returned.`
+
+	formats["color16"] = func(c cpick.ColorValues, args []string) (string, error) {
+		return fmt.Sprintf("%v\n", cpick.Ansi16Code(c)), nil
+	}
+
+	x.Method = func(args []string) error {
+		return run("color16", args)
+	}
+}
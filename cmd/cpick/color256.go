@@ -0,0 +1,30 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/ethanbaker/cpick"
+	"github.com/rwxrob/cmdtab"
+)
+
+func init() {
+	x := cmdtab.New("color256")
+
+	x.Usage = ""
+	x.Summary = "Return the index of the color in the 256 color terminal palette"
+
+	x.Description = `
+	The *color256* subcommand is used to return the index (between 0
+	and 255, inclusive) of a color that is selected when cpick is
+	running in the 256 color terminal palette. If the color was not
+	picked from the palette (with X), the index of the closest color
+	in the palette is returned.`
+
+	formats["color256"] = func(c cpick.ColorValues, args []string) (string, error) {
+		return fmt.Sprintf("%v\n", c.ColorIndex), nil
+	}
+
+	x.Method = func(args []string) error {
+		return run("color256", args)
+	}
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/ethanbaker/cpick"
+	"github.com/rwxrob/cmdtab"
+)
+
+func init() {
+	x := cmdtab.New("colorref")
+
+	x.Usage = ""
+	x.Summary = "Return a Windows COLORREF value with the color"
+
+	x.Description = `
+	The *colorref* subcommand is used to return a Windows COLORREF
+	value for a color that is selected when cpick is running. A
+	COLORREF stores the blue, green, and red values of the color in
+	that order, so the bytes are reversed compared to a hex value.`
+
+	formats["colorref"] = func(c cpick.ColorValues, args []string) (string, error) {
+		return fmt.Sprintf("0x%02X%02X%02X\n", c.RGB.B, c.RGB.G, c.RGB.R), nil
+	}
+
+	x.Method = func(args []string) error {
+		return run("colorref", args)
+	}
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/ethanbaker/cpick"
+	"github.com/rwxrob/cmdtab"
+)
+
+func init() {
+	x := cmdtab.New("contrast")
+
+	x.Usage = "[ratio]"
+	x.Summary = "Return the text color with the highest contrast on the color"
+
+	x.Description = `
+	The *contrast* subcommand is used to return the text color (black
+	or white) with the highest WCAG contrast ratio on a color that is
+	selected when cpick is running, as a hex value. If "ratio" is
+	given, the contrast ratio is added after the text color.`
+
+	formats["contrast"] = func(c cpick.ColorValues, args []string) (string, error) {
+		text, ratio := cpick.SuggestTextColor(c)
+		if len(args) > 0 && args[0] == "ratio" {
+			return fmt.Sprintf("#%v%v%.2f\n", text.Hex, delimiter(" "), ratio), nil
+		}
+
+		return fmt.Sprintf("#%v\n", text.Hex), nil
+	}
+
+	x.Method = func(args []string) error {
+		return run("contrast", args)
+	}
+}
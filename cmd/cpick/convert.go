@@ -0,0 +1,54 @@
+package main
+
+import (
+	"github.com/ethanbaker/cpick"
+	"github.com/rwxrob/cmdtab"
+)
+
+func init() {
+	x := cmdtab.New("convert")
+
+	x.Usage = "<color> [<type>]"
+	x.Summary = "Convert a color to a different type without starting cpick"
+
+	x.Description = `
+	The *convert* subcommand is used to convert a color into one of
+	the other output types without starting the color picker. The
+	color can be given in any of the formats accepted by the search
+	bar (EX: "#ff8800", "rgb: 255 136 0", "hsv: 32 100 100",
+	"hsl: 32 100 50", "cmyk: 0 47 100 0", "decimal: 16746496", or
+	"kelvin: 6500"). Any arguments after the type are passed to the
+	type. If no type is specified, the type in CPICK_DEFAULT_TYPE is
+	used, or "ansi" if it isn't set.`
+
+	x.Method = func(args []string) error {
+		args, err := parseArgs(args)
+		if err != nil {
+			return err
+		}
+		if len(args) == 0 {
+			return x.UsageError()
+		}
+
+		c, err := cpick.Convert(args[0])
+		if err != nil {
+			return err
+		}
+
+		name := cpickCommand.Default
+		if len(args) > 1 {
+			name = args[1]
+			args = args[2:]
+		} else {
+			args = nil
+		}
+
+		closeOutput, err := openOutput()
+		if err != nil {
+			return err
+		}
+		defer closeOutput()
+
+		return output(name, c, args)
+	}
+}
@@ -4,11 +4,18 @@ import (
 	"github.com/rwxrob/cmdtab"
 )
 
+// The version of the cpick command
+const VERSION = "1.2.0"
+
+// The cpick command, whose default subcommand is set when it is executed
+var cpickCommand *cmdtab.Command
+
 func init() {
-	x := cmdtab.New("cpick", "rgb", "hsv", "hsl", "cmyk", "hex", "decimal", "ansi", "escape", "name", "json", "css", "bash")
+	x := cmdtab.New("cpick", "all", "rgb", "rgba", "hsv", "hsl", "hwb", "cmyk", "hex", "decimal", "kelvin", "ansi", "escape", "name", "json", "yaml", "toml", "csv", "css", "bash", "scss", "swift", "swiftui", "plist", "android", "flutter", "qt", "latex", "emacs", "vim", "rust", "colorref", "powershell", "gl", "contrast", "color256", "color16", "convert", "batch", "export", "gradient")
 	x.Default = "ansi"
+	cpickCommand = x
 	x.Summary = "An extensive color picker for the terminal!"
-	x.Version = "1.2.0"
+	x.Version = VERSION
 	x.Author = "Ethan Baker <mail@ethanbaker.dev>"
 	x.Git = "github.com/ethanbaker/cpick"
 	x.Copyright = "(c) Ethan Baker"
@@ -20,19 +20,16 @@ func init() {
 	tag as the hexadecimal color. If no tag is specified, the
 	tag name is "color".`
 
-	x.Method = func(args []string) error {
-		c, err := cpick.Start(false)
-		if err != nil {
-			return err
-		}
-
+	formats["css"] = func(c cpick.ColorValues, args []string) (string, error) {
 		tag := "color"
 		if len(args) > 0 {
 			tag = args[0]
 		}
 
-		fmt.Printf("%v: #%v;\n", tag, c.Hex)
+		return fmt.Sprintf("%v: #%v;\n", tag, c.Hex), nil
+	}
 
-		return nil
+	x.Method = func(args []string) error {
+		return run("css", args)
 	}
 }
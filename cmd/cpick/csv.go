@@ -0,0 +1,50 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"strings"
+
+	"github.com/ethanbaker/cpick"
+	"github.com/rwxrob/cmdtab"
+)
+
+// csvHeaderWritten keeps the header from being repeated when more than one
+// color is printed with -count
+var csvHeaderWritten = false
+
+func init() {
+	x := cmdtab.New("csv")
+
+	x.Usage = ""
+	x.Summary = "Return a csv header and row containing the color info"
+
+	x.Description = `
+	The *csv* subcommand is used to return a csv header and row for a
+	color that is selected when cpick is running. The columns are R,
+	G, B, H, S, V, hex, decimal, and name. The header can be left off
+	with -no-header, which is useful to add rows to an existing file.`
+
+	formats["csv"] = func(c cpick.ColorValues, args []string) (string, error) {
+		var b strings.Builder
+		w := csv.NewWriter(&b)
+
+		if !options.noHeader && !csvHeaderWritten {
+			w.Write([]string{"R", "G", "B", "H", "S", "V", "hex", "decimal", "name"})
+			csvHeaderWritten = true
+		}
+
+		w.Write([]string{
+			fmt.Sprint(c.RGB.R), fmt.Sprint(c.RGB.G), fmt.Sprint(c.RGB.B),
+			fmt.Sprint(c.HSV.H), fmt.Sprint(c.HSV.S), fmt.Sprint(c.HSV.V),
+			"#" + string(c.Hex), fmt.Sprint(c.Decimal), c.Name,
+		})
+
+		w.Flush()
+		return b.String(), w.Error()
+	}
+
+	x.Method = func(args []string) error {
+		return run("csv", args)
+	}
+}
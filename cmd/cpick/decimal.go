@@ -17,14 +17,11 @@ func init() {
 	The *decimal* subcommand is used to return the corresponding decimal 
 	value	for a color that is selected when cpick is running.`
 
-	x.Method = func(args []string) error {
-		c, err := cpick.Start(false)
-		if err != nil {
-			return err
-		}
-
-		fmt.Printf("%v\n", c.Decimal)
+	formats["decimal"] = func(c cpick.ColorValues, args []string) (string, error) {
+		return fmt.Sprintf("%v\n", c.Decimal), nil
+	}
 
-		return nil
+	x.Method = func(args []string) error {
+		return run("decimal", args)
 	}
 }
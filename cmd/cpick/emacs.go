@@ -0,0 +1,39 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/ethanbaker/cpick"
+	"github.com/rwxrob/cmdtab"
+)
+
+func init() {
+	x := cmdtab.New("emacs")
+
+	x.Usage = "[foreground|background]"
+	x.Summary = "Return an Emacs face attribute with the color"
+
+	x.Description = `
+	The *emacs* subcommand is used to return a face attribute list
+	for a color that is selected when cpick is running, which can be
+	pasted into a face definition. If "background" is given, the
+	color is used as the background of the face instead of the
+	foreground.`
+
+	formats["emacs"] = func(c cpick.ColorValues, args []string) (string, error) {
+		attribute := "foreground"
+		if len(args) > 0 {
+			attribute = args[0]
+		}
+
+		if attribute != "foreground" && attribute != "background" {
+			return "", fmt.Errorf("Invalid emacs attribute %q, expected foreground or background", attribute)
+		}
+
+		return fmt.Sprintf("(:%v \"#%v\")\n", attribute, c.Hex), nil
+	}
+
+	x.Method = func(args []string) error {
+		return run("emacs", args)
+	}
+}
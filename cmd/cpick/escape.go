@@ -1,7 +1,6 @@
 package main
 
 import (
-	"fmt"
 	"strings"
 
 	"github.com/ethanbaker/cpick"
@@ -20,14 +19,11 @@ func init() {
 	This will return a line of characters that can be used to represent 
 	a color on a terminal.`
 
-	x.Method = func(args []string) error {
-		c, err := cpick.Start(false)
-		if err != nil {
-			return err
-		}
-
-		fmt.Printf("\\033[" + strings.Split(string(c.Ansi), "[")[1])
+	formats["escape"] = func(c cpick.ColorValues, args []string) (string, error) {
+		return "\\033[" + strings.Split(string(c.Ansi), "[")[1], nil
+	}
 
-		return nil
+	x.Method = func(args []string) error {
+		return run("escape", args)
 	}
 }
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"flag"
+	"io"
+	"os"
+
+	"github.com/ethanbaker/cpick"
+	"github.com/rwxrob/cmdtab"
+)
+
+func init() {
+	x := cmdtab.New("export")
+
+	x.Usage = "[-gpl <file>] [-ase <file>]"
+	x.Summary = "Export the preset colors to a palette file"
+
+	x.Description = `
+	The *export* subcommand is used to write every preset color to a
+	palette file without starting the color picker. Use -gpl to write
+	a GIMP palette and -ase to write an Adobe Swatch Exchange file.
+	If the file is "-", the palette is written to standard output.`
+
+	x.Method = func(args []string) error {
+		fs := flag.NewFlagSet("export", flag.ContinueOnError)
+		fs.SetOutput(io.Discard)
+
+		gpl := fs.String("gpl", "", "")
+		ase := fs.String("ase", "", "")
+
+		if err := fs.Parse(args); err != nil {
+			return err
+		}
+		if *gpl == "" && *ase == "" {
+			return x.UsageError()
+		}
+
+		if *gpl != "" {
+			if err := exportFile(*gpl, cpick.ExportGPL); err != nil {
+				return err
+			}
+		}
+		if *ase != "" {
+			if err := exportFile(*ase, cpick.ExportASE); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+// exportFile writes a palette to the given path using the export function
+func exportFile(path string, export func(w io.Writer) error) error {
+	if path == "-" {
+		return export(os.Stdout)
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if err := export(f); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
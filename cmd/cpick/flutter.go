@@ -0,0 +1,31 @@
+package main
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/ethanbaker/cpick"
+	"github.com/rwxrob/cmdtab"
+)
+
+func init() {
+	x := cmdtab.New("flutter")
+
+	x.Usage = ""
+	x.Summary = "Return a Flutter Color with the color in ARGB format"
+
+	x.Description = `
+	The *flutter* subcommand is used to return a Flutter Color for a
+	color that is selected when cpick is running. The color is written
+	as an uppercase ARGB hex value, where the alpha can be changed on
+	the saturation-value table.`
+
+	formats["flutter"] = func(c cpick.ColorValues, args []string) (string, error) {
+		a := int(math.Round(c.Alpha * 255))
+		return fmt.Sprintf("Color(0x%02X%02X%02X%02X)\n", a, c.RGB.R, c.RGB.G, c.RGB.B), nil
+	}
+
+	x.Method = func(args []string) error {
+		return run("flutter", args)
+	}
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/ethanbaker/cpick"
+	"github.com/rwxrob/cmdtab"
+)
+
+func init() {
+	x := cmdtab.New("gl")
+
+	x.Usage = "[alpha]"
+	x.Summary = "Return the RGB values of the color normalized between 0 and 1"
+
+	x.Description = `
+	The *gl* subcommand is used to return the RGB values of a color
+	that is selected when cpick is running, normalized between 0 and 1
+	with three decimals and separated by spaces. If "alpha" is given,
+	the alpha is added as a fourth value.`
+
+	formats["gl"] = func(c cpick.ColorValues, args []string) (string, error) {
+		d := delimiter(" ")
+		s := fmt.Sprintf("%.3f%v%.3f%v%.3f", float64(c.RGB.R)/255, d, float64(c.RGB.G)/255, d, float64(c.RGB.B)/255)
+		if len(args) > 0 && args[0] == "alpha" {
+			s += fmt.Sprintf("%v%.3f", d, c.Alpha)
+		}
+
+		return s + "\n", nil
+	}
+
+	x.Method = func(args []string) error {
+		return run("gl", args)
+	}
+}
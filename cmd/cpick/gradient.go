@@ -0,0 +1,74 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/ethanbaker/cpick"
+	"github.com/rwxrob/cmdtab"
+)
+
+func init() {
+	x := cmdtab.New("gradient")
+
+	x.Usage = "<start> <end> [<steps>] [<type>]"
+	x.Summary = "Print a gradient between two colors without starting cpick"
+
+	x.Description = `
+	The *gradient* subcommand is used to print a gradient of a number
+	of steps between two colors, including both of them, without
+	starting the color picker. The colors can be given in any of the
+	formats accepted by the search bar, and each color of the gradient
+	is printed on its own line. Any arguments after the type are passed
+	to the type. By default, the number of steps is 9 and the type is
+	"hex".`
+
+	x.Method = func(args []string) error {
+		args, err := parseArgs(args)
+		if err != nil {
+			return err
+		}
+		if len(args) < 2 {
+			return x.UsageError()
+		}
+
+		start, err := cpick.Convert(args[0])
+		if err != nil {
+			return err
+		}
+		end, err := cpick.Convert(args[1])
+		if err != nil {
+			return err
+		}
+		args = args[2:]
+
+		steps := cpick.GRADIENT_STEPS
+		if len(args) > 0 {
+			steps, err = strconv.Atoi(args[0])
+			if err != nil || steps < 1 {
+				return fmt.Errorf("Invalid number of steps %q, expected a positive number", args[0])
+			}
+			args = args[1:]
+		}
+
+		name := "hex"
+		if len(args) > 0 {
+			name = args[0]
+			args = args[1:]
+		}
+
+		closeOutput, err := openOutput()
+		if err != nil {
+			return err
+		}
+		defer closeOutput()
+
+		for _, c := range cpick.Gradient(start, end, steps) {
+			if err := output(name, c, args); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
@@ -2,6 +2,8 @@ package main
 
 import (
 	"fmt"
+	"math"
+	"strings"
 
 	"github.com/ethanbaker/cpick"
 	"github.com/rwxrob/cmdtab"
@@ -15,16 +17,23 @@ func init() {
 
 	x.Description = `
 	The *hex* subcommand is used to return the corresponding hex value
-	for a color that is selected when cpick is running.`
+	for a color that is selected when cpick is running. If the alpha
+	of the color isn't full, it is added as two more hex digits.`
 
-	x.Method = func(args []string) error {
-		c, err := cpick.Start(false)
-		if err != nil {
-			return err
+	formats["hex"] = func(c cpick.ColorValues, args []string) (string, error) {
+		if c.Alpha >= 1 {
+			return fmt.Sprintf("#%v\n", c.Hex), nil
 		}
 
-		fmt.Printf("#%v\n", c.Hex)
+		a := fmt.Sprintf("%02x", int(math.Round(c.Alpha*255)))
+		if options.uppercase {
+			a = strings.ToUpper(a)
+		}
 
-		return nil
+		return fmt.Sprintf("#%v%v\n", c.Hex, a), nil
+	}
+
+	x.Method = func(args []string) error {
+		return run("hex", args)
 	}
 }
@@ -16,16 +16,15 @@ func init() {
 	x.Description = `
 	The *hsl* subcommand is used to return the HSL values (0-359 for 
 	hue, 0-100 for saturation and lightness) for a color that is selected 
-	when cpick is running. The HSL values are separated by semi-colons.`
+	when cpick is running. The HSL values are separated by semi-colons, or by
+	the delimiter given with -delimiter.`
 
-	x.Method = func(args []string) error {
-		c, err := cpick.Start(false)
-		if err != nil {
-			return err
-		}
-
-		fmt.Printf("%v;%v;%v\n", c.HSL.H, c.HSL.S, c.HSL.L)
+	formats["hsl"] = func(c cpick.ColorValues, args []string) (string, error) {
+		d := delimiter(";")
+		return fmt.Sprintf("%v%v%v%v%v\n", c.HSL.H, d, c.HSL.S, d, c.HSL.L), nil
+	}
 
-		return nil
+	x.Method = func(args []string) error {
+		return run("hsl", args)
 	}
 }
@@ -16,16 +16,15 @@ func init() {
 	x.Description = `
 	The *hsv* subcommand is used to return the HSV values (0-359 for 
 	hue, 0-100 for saturation and value) for a color that is selected 
-	when cpick is running. The HSV values are separated by semi-colons.`
+	when cpick is running. The HSV values are separated by semi-colons, or by
+	the delimiter given with -delimiter.`
 
-	x.Method = func(args []string) error {
-		c, err := cpick.Start(false)
-		if err != nil {
-			return err
-		}
-
-		fmt.Printf("%v;%v;%v\n", c.HSV.H, c.HSV.S, c.HSV.V)
+	formats["hsv"] = func(c cpick.ColorValues, args []string) (string, error) {
+		d := delimiter(";")
+		return fmt.Sprintf("%v%v%v%v%v\n", c.HSV.H, d, c.HSV.S, d, c.HSV.V), nil
+	}
 
-		return nil
+	x.Method = func(args []string) error {
+		return run("hsv", args)
 	}
 }
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/ethanbaker/cpick"
+	"github.com/rwxrob/cmdtab"
+)
+
+func init() {
+	x := cmdtab.New("hwb")
+
+	x.Usage = ""
+	x.Summary = "Return a css hwb function with the color"
+
+	x.Description = `
+	The *hwb* subcommand is used to return a css hwb function (0-359
+	for hue, 0-100 for whiteness and blackness) for a color that is
+	selected when cpick is running.`
+
+	formats["hwb"] = func(c cpick.ColorValues, args []string) (string, error) {
+		return fmt.Sprintf("hwb(%v %v%% %v%%)\n", c.HWB.H, c.HWB.W, c.HWB.B), nil
+	}
+
+	x.Method = func(args []string) error {
+		return run("hwb", args)
+	}
+}
@@ -16,21 +16,27 @@ func init() {
 
 	x.Description = `
 	The *json* subcommand is used to return the corresponding json object
-	for a color that is selected when cpick is running.`
-
-	x.Method = func(args []string) error {
-		c, err := cpick.Start(false)
-		if err != nil {
-			return err
+	for a color that is selected when cpick is running. The keys are
+	lowercase, including the keys of the nested color types (EX:
+	"rgb": {"r": 255, "g": 0, "b": 0}). The object is printed on a
+	single line if -compact is given.`
+
+	formats["json"] = func(c cpick.ColorValues, args []string) (string, error) {
+		var s []byte
+		var err error
+		if options.compact {
+			s, err = json.Marshal(c)
+		} else {
+			s, err = json.MarshalIndent(c, "", "    ")
 		}
-
-		s, err := json.MarshalIndent(c, "", "    ")
 		if err != nil {
-			return err
+			return "", err
 		}
 
-		fmt.Println(string(s))
+		return fmt.Sprintln(string(s)), nil
+	}
 
-		return nil
+	x.Method = func(args []string) error {
+		return run("json", args)
 	}
 }
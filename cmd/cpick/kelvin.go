@@ -0,0 +1,31 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/ethanbaker/cpick"
+	"github.com/rwxrob/cmdtab"
+)
+
+func init() {
+	x := cmdtab.New("kelvin")
+
+	x.Usage = ""
+	x.Summary = "Return the nearest color temperature of the color"
+
+	x.Description = `
+	The *kelvin* subcommand is used to return the nearest color
+	temperature (between 1000K and 40000K, rounded to 100K) for a color
+	that is selected when cpick is running. Only the balance of the
+	channels is compared, so a color and its darker shades have the
+	same temperature. Colors that aren't close to a blackbody color,
+	such as greens and purples, still get the nearest temperature.`
+
+	formats["kelvin"] = func(c cpick.ColorValues, args []string) (string, error) {
+		return fmt.Sprintf("%vK\n", cpick.RGBtoKelvin(c.RGB)), nil
+	}
+
+	x.Method = func(args []string) error {
+		return run("kelvin", args)
+	}
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethanbaker/cpick"
+	"github.com/rwxrob/cmdtab"
+)
+
+func init() {
+	x := cmdtab.New("latex")
+
+	x.Usage = "<name>"
+	x.Summary = "Return a LaTeX xcolor definition with the color"
+
+	x.Description = `
+	The *latex* subcommand is used to return an xcolor definition for
+	a color that is selected when cpick is running. The color is
+	written as an uppercase hex value. A specific name can be
+	specified, which will be used as the name of the color. If no
+	name is specified, the name is "custom".`
+
+	formats["latex"] = func(c cpick.ColorValues, args []string) (string, error) {
+		name := "custom"
+		if len(args) > 0 {
+			name = args[0]
+		}
+
+		return fmt.Sprintf("\\definecolor{%v}{HTML}{%v}\n", name, strings.ToUpper(string(c.Hex))), nil
+	}
+
+	x.Method = func(args []string) error {
+		return run("latex", args)
+	}
+}
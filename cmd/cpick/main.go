@@ -1,7 +1,39 @@
 package main
 
-import "github.com/rwxrob/cmdtab"
+import (
+	"errors"
+	"fmt"
+
+	"github.com/rwxrob/cmdtab"
+)
 
 func main() {
+	// The type is only read once every type is registered
+	cpickCommand.Default = defaultType()
+
+	// -version can be given to any type, which returns errVersion once its
+	// flags are parsed
+	for _, commands := range []map[string]*cmdtab.Command{cmdtab.Visible(), cmdtab.Hidden()} {
+		for _, x := range commands {
+			if x.Method != nil {
+				x.Method = printVersion(x.Method)
+			}
+		}
+	}
+
 	cmdtab.Execute("cpick")
 }
+
+// printVersion wraps the method of a type so that the version is printed
+// instead of an error when -version is given
+func printVersion(method func(args []string) error) func(args []string) error {
+	return func(args []string) error {
+		err := method(args)
+		if errors.Is(err, errVersion) {
+			fmt.Print(versionText())
+			return nil
+		}
+
+		return err
+	}
+}
@@ -1,8 +1,6 @@
 package main
 
 import (
-	"fmt"
-
 	"github.com/ethanbaker/cpick"
 	"github.com/rwxrob/cmdtab"
 )
@@ -18,14 +16,11 @@ func init() {
 	for a color that is selected when cpick is running. If the 
 	selected color has no name, an empty string will be returned.`
 
-	x.Method = func(args []string) error {
-		c, err := cpick.Start(false)
-		if err != nil {
-			return err
-		}
-
-		fmt.Printf(c.Name)
+	formats["name"] = func(c cpick.ColorValues, args []string) (string, error) {
+		return c.Name, nil
+	}
 
-		return nil
+	x.Method = func(args []string) error {
+		return run("name", args)
 	}
 }
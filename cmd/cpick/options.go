@@ -0,0 +1,507 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"runtime/debug"
+	"strings"
+	"text/template"
+
+	color "github.com/ethanbaker/colors"
+	"github.com/ethanbaker/cpick"
+)
+
+// The exit status used when the application is quit without a color being
+// selected, so that scripts can tell a cancel apart from an error
+const EXIT_CANCELED = 2
+
+// errVersion is returned by parseArgs when -version is given
+var errVersion = errors.New("version requested")
+
+// options holds the flags that can be passed to every output type
+var options struct {
+	initial   string
+	clipboard bool
+	simulate  string
+	colorMode string
+	grayscale bool
+	palette   string
+	format    string
+	uppercase bool
+	delimiter string
+	noNewline bool
+	count     int
+	step      int
+	large     bool
+	noMouse   bool
+	wrap      bool
+	noPresets bool
+	random    bool
+	noRestore bool
+	names     bool
+	noHeader  bool
+	compact   bool
+	output    string
+	append    bool
+	version   bool
+	cmyk      bool
+	svRows    int
+	single    bool
+	theme     string
+	sandbox   bool
+	list      bool
+}
+
+// out is where the output of the types is written, which is stdout unless
+// -output is given
+var out io.Writer = os.Stdout
+
+// formats holds the function used to format the selected color for each
+// output type. Any arguments left after the flags are parsed are passed
+// to the function.
+var formats = map[string]func(c cpick.ColorValues, args []string) (string, error){}
+
+// parseArgs parses the flags out of the given arguments and returns the
+// arguments that are left over
+func parseArgs(args []string) ([]string, error) {
+	fs := flag.NewFlagSet("cpick", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	fs.StringVar(&options.initial, "i", "", "")
+	fs.StringVar(&options.initial, "initial", "", "")
+	fs.BoolVar(&options.clipboard, "clipboard", false, "")
+	fs.StringVar(&options.simulate, "simulate", "", "")
+	fs.StringVar(&options.colorMode, "color-mode", "", "")
+	fs.BoolVar(&options.grayscale, "grayscale", false, "")
+	fs.BoolVar(&options.cmyk, "cmyk", false, "")
+	fs.BoolVar(&options.list, "list", false, "")
+	fs.StringVar(&options.palette, "palette", "", "")
+	fs.StringVar(&options.format, "format", "", "")
+	fs.BoolVar(&options.uppercase, "u", false, "")
+	fs.BoolVar(&options.uppercase, "uppercase", false, "")
+	fs.StringVar(&options.delimiter, "d", "", "")
+	fs.StringVar(&options.delimiter, "delimiter", "", "")
+	fs.BoolVar(&options.noNewline, "n", false, "")
+	fs.BoolVar(&options.noNewline, "no-newline", false, "")
+	fs.IntVar(&options.count, "count", 1, "")
+	fs.IntVar(&options.step, "step", 0, "")
+	fs.IntVar(&options.svRows, "sv-rows", 0, "")
+	fs.BoolVar(&options.single, "single-tint", false, "")
+	fs.StringVar(&options.theme, "theme", "", "")
+	fs.BoolVar(&options.sandbox, "sandbox", false, "")
+	fs.BoolVar(&options.large, "preview-large", false, "")
+	fs.BoolVar(&options.noMouse, "no-mouse", false, "")
+	fs.BoolVar(&options.wrap, "wrap", false, "")
+	fs.BoolVar(&options.noPresets, "no-presets", false, "")
+	fs.BoolVar(&options.random, "random", false, "")
+	fs.BoolVar(&options.noRestore, "no-restore", false, "")
+	fs.BoolVar(&options.names, "generate-names", false, "")
+	fs.BoolVar(&options.noHeader, "no-header", false, "")
+	fs.BoolVar(&options.compact, "compact", false, "")
+	fs.StringVar(&options.output, "o", "", "")
+	fs.StringVar(&options.output, "output", "", "")
+	fs.BoolVar(&options.append, "append", false, "")
+	fs.BoolVar(&options.version, "v", false, "")
+	fs.BoolVar(&options.version, "version", false, "")
+
+	// Flags can come before or after the other arguments
+	var rest []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+
+		args = fs.Args()
+		if len(args) == 0 {
+			break
+		}
+
+		rest = append(rest, args[0])
+		args = args[1:]
+	}
+
+	cpick.GenerateNames = options.names
+
+	// Parse the -format template before cpick starts, so that a mistake in
+	// it doesn't throw away the picked color
+	formatTemplate = nil
+	if options.format != "" {
+		t, err := template.New("format").Parse(options.format)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid -format template: %v", err)
+		}
+		formatTemplate = t
+	}
+
+	// The version is printed by main, so that nothing else is started
+	if options.version {
+		return nil, errVersion
+	}
+
+	return rest, nil
+}
+
+// versionText returns the version of cpick, along with the module version
+// and VCS revision it was built from if they are embedded in the binary
+func versionText() string {
+	text := fmt.Sprintf("cpick %v\n", VERSION)
+
+	// Build info isn't embedded in every binary (EX: some test binaries)
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return text + "Build info: not available\n"
+	}
+
+	// go run leaves out the VCS info, and the module version is "(devel)"
+	text += fmt.Sprintf("Module: %v %v\n", info.Main.Path, info.Main.Version)
+
+	var revision, modified string
+	for _, s := range info.Settings {
+		switch s.Key {
+		case "vcs.revision":
+			revision = s.Value
+		case "vcs.modified":
+			modified = s.Value
+		}
+	}
+
+	if revision == "" {
+		return text + "Revision: unknown\n"
+	}
+	if modified == "true" {
+		revision += " (modified)"
+	}
+
+	return text + fmt.Sprintf("Revision: %v\n", revision)
+}
+
+// canceled exits quietly with EXIT_CANCELED if err is cpick.ErrCanceled and
+// returns err otherwise
+func canceled(err error) error {
+	if errors.Is(err, cpick.ErrCanceled) {
+		os.Exit(EXIT_CANCELED)
+	}
+
+	return err
+}
+
+// run starts cpick and prints the selected color in the given output type
+func run(name string, args []string) error {
+	args, err := parseArgs(args)
+	if err != nil {
+		return err
+	}
+
+	if options.random {
+		return randomColors(name, args)
+	}
+
+	config := cpick.Config{Grayscale: options.grayscale, Palette: options.palette, Step: options.step, SVRows: options.svRows, SingleTint: options.single, LargePreview: options.large, NoMouse: options.noMouse, HueWrap: options.wrap, NoPresets: options.noPresets, NoRestore: options.noRestore, CMYK: options.cmyk, List: options.list}
+
+	if options.simulate != "" {
+		switch options.simulate {
+		case "protanopia", "deuteranopia", "tritanopia":
+			config.Simulate = options.simulate
+		default:
+			fmt.Fprintf(os.Stderr, "Invalid simulation %q, expected protanopia, deuteranopia, or tritanopia\n", options.simulate)
+		}
+	}
+
+	if options.colorMode != "" {
+		switch options.colorMode {
+		case "truecolor", "256":
+			config.ColorMode = options.colorMode
+		default:
+			fmt.Fprintf(os.Stderr, "Invalid color mode %q, expected truecolor or 256\n", options.colorMode)
+		}
+	}
+
+	if options.theme != "" {
+		switch options.theme {
+		case "dark", "light", "mono":
+			config.Theme = options.theme
+		default:
+			fmt.Fprintf(os.Stderr, "Invalid theme %q, expected dark, light, or mono\n", options.theme)
+		}
+	}
+
+	if options.initial != "" {
+		// Convert reads hex values the same way as Config.Initial, and the
+		// other color formats are left out
+		if _, err := cpick.Convert(options.initial); err == nil && !strings.Contains(options.initial, ":") {
+			config.Initial = options.initial
+		} else {
+			fmt.Fprintf(os.Stderr, "Invalid initial color %q, expected a hex value (EX: #3366ff, #36f, or #3366ffcc)\n", options.initial)
+		}
+	} else if initial, ok := stdinColor(); ok {
+		config.Initial = initial
+	}
+
+	if options.sandbox {
+		return sandbox(config, name, args)
+	}
+
+	if options.count > 1 {
+		colors, err := cpick.StartMulti(config, options.count)
+		if err != nil {
+			return canceled(err)
+		}
+
+		closeOutput, err := openOutput()
+		if err != nil {
+			return err
+		}
+		defer closeOutput()
+
+		for _, c := range colors {
+			if err := outputLine(name, c, args); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	c, err := cpick.StartWithConfig(config)
+	if err != nil {
+		return canceled(err)
+	}
+
+	closeOutput, err := openOutput()
+	if err != nil {
+		return err
+	}
+	defer closeOutput()
+
+	return output(name, c, args)
+}
+
+// randomColors prints random colors in the given output type without
+// starting cpick, one for each color of -count
+func randomColors(name string, args []string) error {
+	closeOutput, err := openOutput()
+	if err != nil {
+		return err
+	}
+	defer closeOutput()
+
+	count := options.count
+	if count < 1 {
+		count = 1
+	}
+
+	// Only more than one color is kept on separate lines, like -count
+	write := output
+	if count > 1 {
+		write = outputLine
+	}
+
+	for i := 0; i < count; i++ {
+		if err := write(name, cpick.Random(), args); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// sandbox runs cpick in sandbox mode, where the noted colors are written to
+// the -output file if one is given. Quitting is not an error in sandbox mode.
+func sandbox(config cpick.Config, name string, args []string) error {
+	config.Sandbox = true
+
+	// The picker is drawn on stdout, so the colors are only written to a file
+	if options.output != "" {
+		closeOutput, err := openOutput()
+		if err != nil {
+			return err
+		}
+		defer closeOutput()
+
+		config.SandboxFunc = func(c cpick.ColorValues) error {
+			return output(name, c, args)
+		}
+	}
+
+	if _, err := cpick.StartWithConfig(config); err != nil && !errors.Is(err, cpick.ErrCanceled) {
+		return err
+	}
+
+	return nil
+}
+
+// stdinColor reads the initial color from stdin if a color is piped in
+// instead of stdin being a terminal. The color can be in any format accepted
+// by Convert, and it is returned as a hex value.
+func stdinColor() (string, bool) {
+	info, err := os.Stdin.Stat()
+	if err != nil || info.Mode()&os.ModeCharDevice != 0 {
+		return "", false
+	}
+
+	scanner := bufio.NewScanner(os.Stdin)
+	if !scanner.Scan() {
+		return "", false
+	}
+
+	input := strings.TrimSpace(scanner.Text())
+	if input == "" {
+		return "", false
+	}
+
+	c, err := cpick.Convert(input)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid initial color %q on stdin: %v\n", input, err)
+		return "", false
+	}
+
+	return "#" + string(c.Hex), true
+}
+
+// openOutput points the output at the -output file if one is given, which
+// is truncated unless -append is given. The returned function closes the
+// file.
+func openOutput() (func() error, error) {
+	out, heldNewline = os.Stdout, false
+	if options.output == "" {
+		return func() error { return nil }, nil
+	}
+
+	flags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	if options.append {
+		flags = os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	}
+
+	f, err := os.OpenFile(options.output, flags, 0644)
+	if err != nil {
+		return nil, err
+	}
+	out = f
+
+	return f.Close, nil
+}
+
+// output prints a color in the given output type, or with the -format
+// template if one is given
+func output(name string, c cpick.ColorValues, args []string) error {
+	s, err := formatOutput(name, c, args)
+	if err != nil {
+		return err
+	}
+
+	return writeOutput(s)
+}
+
+// outputLine prints a color the same way as output, but always ends it with
+// a newline, so that colors printed one after another stay on their own
+// lines in the types without one (EX: name and escape)
+func outputLine(name string, c cpick.ColorValues, args []string) error {
+	s, err := formatOutput(name, c, args)
+	if err != nil {
+		return err
+	}
+
+	if !strings.HasSuffix(s, "\n") {
+		s += "\n"
+	}
+
+	return writeOutput(s)
+}
+
+// formatOutput formats a color in the given output type, or with the
+// -format template if one is given
+func formatOutput(name string, c cpick.ColorValues, args []string) (string, error) {
+	format, ok := formats[name]
+	if options.format != "" {
+		format, ok = templateFormat, true
+	}
+	if !ok {
+		return "", fmt.Errorf("%v is not an output type", name)
+	}
+
+	// Only the hex value changes case, the "#" and other values are kept
+	if options.uppercase {
+		c.Hex = color.Hex(strings.ToUpper(string(c.Hex)))
+	}
+
+	return format(c, args)
+}
+
+// writeOutput prints formatted output and copies it to the clipboard if
+// -clipboard is given
+func writeOutput(s string) error {
+	if err := printOutput(s); err != nil {
+		return err
+	}
+
+	if options.clipboard {
+		if err := cpick.CopyToClipboard(strings.TrimSuffix(s, "\n")); err != nil {
+			fmt.Fprintf(os.Stderr, "Could not copy the color to the clipboard: %v\n", err)
+		}
+	}
+
+	return nil
+}
+
+// defaultType returns the output type used when no type is given, which is
+// the type in CPICK_DEFAULT_TYPE or ansi if it isn't set
+func defaultType() string {
+	name := os.Getenv("CPICK_DEFAULT_TYPE")
+	if name == "" {
+		return "ansi"
+	}
+
+	if _, ok := formats[name]; !ok {
+		fmt.Fprintf(os.Stderr, "Invalid type %q in CPICK_DEFAULT_TYPE, using ansi\n", name)
+		return "ansi"
+	}
+
+	return name
+}
+
+// delimiter returns the delimiter given with -delimiter, or def if none was
+// given
+func delimiter(def string) string {
+	if options.delimiter == "" {
+		return def
+	}
+
+	return options.delimiter
+}
+
+// The parsed -format template, or nil if no template is given
+var formatTemplate *template.Template
+
+// Whether the newline of the last printed color was left off with -no-newline
+var heldNewline bool
+
+// printOutput writes the output of a type, leaving off the trailing newline
+// if -no-newline is given. When more than one color is printed, the newline
+// is only left off after the last color, so the colors stay on their own
+// lines.
+func printOutput(s string) error {
+	if options.noNewline {
+		if heldNewline {
+			s = "\n" + s
+		}
+		heldNewline = strings.HasSuffix(s, "\n")
+		s = strings.TrimSuffix(s, "\n")
+	}
+
+	_, err := fmt.Fprint(out, s)
+	return err
+}
+
+// templateFormat formats a color with the -format template
+func templateFormat(c cpick.ColorValues, args []string) (string, error) {
+	var b strings.Builder
+	if err := formatTemplate.Execute(&b, c); err != nil {
+		return "", fmt.Errorf("Could not format the color with the -format template: %v", err)
+	}
+
+	return b.String() + "\n", nil
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rwxrob/cmdtab"
+)
+
+func Test_ParseArgsVersion(t *testing.T) {
+	for _, v := range []string{"-v", "-version", "--version"} {
+		if _, err := parseArgs([]string{"hex", v}); !errors.Is(err, errVersion) {
+			t.Errorf("parseArgs is not properly returning errVersion for %v!\nOutput: %v\n", v, err)
+		}
+	}
+
+	if args, err := parseArgs([]string{"-n", "css", "body"}); err != nil || len(args) != 2 {
+		t.Errorf("parseArgs is not properly returning the arguments without -version!\nOutput: %v, %v\n", args, err)
+	}
+}
+
+func Test_RandomCount(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	output := filepath.Join(t.TempDir(), "colors.txt")
+
+	// Every color is on its own line, even in types without a newline
+	var expected = map[string]int{"": 3, "-n": 2}
+	for flag, newlines := range expected {
+		args := []string{"-random", "-count", "3", "-o", output}
+		if flag != "" {
+			args = append(args, flag)
+		}
+		if err := cmdtab.Call("escape", args); err != nil {
+			t.Fatal(err)
+		}
+
+		raw, err := os.ReadFile(output)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.Count(string(raw), "\n") != newlines || strings.Count(string(raw), "\\033[") != 3 {
+			t.Errorf("-count is not properly printing the colors on their own lines with %q!\nOutput: %q\n", flag, raw)
+		}
+	}
+}
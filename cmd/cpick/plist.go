@@ -0,0 +1,39 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/ethanbaker/cpick"
+	"github.com/rwxrob/cmdtab"
+)
+
+func init() {
+	x := cmdtab.New("plist")
+
+	x.Usage = "[alpha]"
+	x.Summary = "Return an XML property list dictionary with the color"
+
+	x.Description = `
+	The *plist* subcommand is used to return an XML property list
+	dictionary for a color that is selected when cpick is running,
+	which can be pasted into Apple property lists and asset catalogs.
+	The red, green, and blue keys are always written in that order
+	with values between 0 and 1 with three decimals. If "alpha" is
+	given, an alpha key is added after them.`
+
+	formats["plist"] = func(c cpick.ColorValues, args []string) (string, error) {
+		s := "<dict>\n"
+		s += fmt.Sprintf("  <key>red</key>\n  <real>%.3f</real>\n", float64(c.RGB.R)/255)
+		s += fmt.Sprintf("  <key>green</key>\n  <real>%.3f</real>\n", float64(c.RGB.G)/255)
+		s += fmt.Sprintf("  <key>blue</key>\n  <real>%.3f</real>\n", float64(c.RGB.B)/255)
+		if len(args) > 0 && args[0] == "alpha" {
+			s += fmt.Sprintf("  <key>alpha</key>\n  <real>%.3f</real>\n", c.Alpha)
+		}
+
+		return s + "</dict>\n", nil
+	}
+
+	x.Method = func(args []string) error {
+		return run("plist", args)
+	}
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/ethanbaker/cpick"
+	"github.com/rwxrob/cmdtab"
+)
+
+func init() {
+	x := cmdtab.New("powershell")
+
+	x.Usage = "[alpha]"
+	x.Summary = "Return a PowerShell System.Drawing.Color with the color"
+
+	x.Description = `
+	The *powershell* subcommand is used to return a PowerShell
+	expression that creates a System.Drawing.Color from the RGB
+	values of a color that is selected when cpick is running. If
+	"alpha" is given, the alpha of the color (between 0 and 255) is
+	passed as the first value.`
+
+	formats["powershell"] = func(c cpick.ColorValues, args []string) (string, error) {
+		if len(args) > 0 && args[0] == "alpha" {
+			a := int(math.Round(c.Alpha * 255))
+			return fmt.Sprintf("[System.Drawing.Color]::FromArgb(%v,%v,%v,%v)\n", a, c.RGB.R, c.RGB.G, c.RGB.B), nil
+		}
+
+		return fmt.Sprintf("[System.Drawing.Color]::FromArgb(%v,%v,%v)\n", c.RGB.R, c.RGB.G, c.RGB.B), nil
+	}
+
+	x.Method = func(args []string) error {
+		return run("powershell", args)
+	}
+}
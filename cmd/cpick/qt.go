@@ -0,0 +1,41 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/ethanbaker/cpick"
+	"github.com/rwxrob/cmdtab"
+)
+
+func init() {
+	x := cmdtab.New("qt")
+
+	x.Usage = "[stylesheet|qcolor]"
+	x.Summary = "Return a Qt stylesheet line or QColor initializer with the color"
+
+	x.Description = `
+	The *qt* subcommand is used to return a Qt stylesheet line for a
+	color that is selected when cpick is running. If "qcolor" is
+	given, a QColor initializer with the RGB values of the color is
+	returned instead.`
+
+	formats["qt"] = func(c cpick.ColorValues, args []string) (string, error) {
+		form := "stylesheet"
+		if len(args) > 0 {
+			form = args[0]
+		}
+
+		switch form {
+		case "stylesheet":
+			return fmt.Sprintf("color: #%v;\n", c.Hex), nil
+		case "qcolor":
+			return fmt.Sprintf("QColor(%v, %v, %v)\n", c.RGB.R, c.RGB.G, c.RGB.B), nil
+		}
+
+		return "", fmt.Errorf("Invalid qt form %q, expected stylesheet or qcolor", form)
+	}
+
+	x.Method = func(args []string) error {
+		return run("qt", args)
+	}
+}
@@ -16,16 +16,15 @@ func init() {
 	x.Description = `
 	The *rgb* subcommand is used to return the corresponding RGB  
 	values (between 0 and 255, inclusive) for a color that is selected
-	when cpick is running. The RGB values are separated by semi-colons.`
+	when cpick is running. The RGB values are separated by semi-colons, or by
+	the delimiter given with -delimiter.`
 
-	x.Method = func(args []string) error {
-		c, err := cpick.Start(false)
-		if err != nil {
-			return err
-		}
-
-		fmt.Printf("%v;%v;%v\n", c.RGB.R, c.RGB.G, c.RGB.B)
+	formats["rgb"] = func(c cpick.ColorValues, args []string) (string, error) {
+		d := delimiter(";")
+		return fmt.Sprintf("%v%v%v%v%v\n", c.RGB.R, d, c.RGB.G, d, c.RGB.B), nil
+	}
 
-		return nil
+	x.Method = func(args []string) error {
+		return run("rgb", args)
 	}
 }
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ethanbaker/cpick"
+	"github.com/rwxrob/cmdtab"
+)
+
+func init() {
+	x := cmdtab.New("rgba")
+
+	x.Usage = ""
+	x.Summary = "Return a css rgba function with the alpha of the color"
+
+	x.Description = `
+	The *rgba* subcommand is used to return a css rgba function for
+	a color that is selected when cpick is running. The alpha value
+	(between 0 and 1, inclusive) can be changed on the
+	saturation-value table.`
+
+	formats["rgba"] = func(c cpick.ColorValues, args []string) (string, error) {
+		// Always show the alpha as a decimal (EX: 1.0)
+		a := strconv.FormatFloat(c.Alpha, 'f', -1, 64)
+		if !strings.Contains(a, ".") {
+			a += ".0"
+		}
+
+		return fmt.Sprintf("rgba(%v,%v,%v,%v)\n", c.RGB.R, c.RGB.G, c.RGB.B, a), nil
+	}
+
+	x.Method = func(args []string) error {
+		return run("rgba", args)
+	}
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethanbaker/cpick"
+	"github.com/rwxrob/cmdtab"
+)
+
+func init() {
+	x := cmdtab.New("rust")
+
+	x.Usage = "[array|tuple] [u8|f32]"
+	x.Summary = "Return a Rust array or tuple literal with the RGB values of the color"
+
+	x.Description = `
+	The *rust* subcommand is used to return a Rust array literal with
+	the RGB values of a color that is selected when cpick is running.
+	If "tuple" is given, a tuple literal is returned instead. If "f32"
+	is given, the values are normalized between 0 and 1 with three
+	decimals instead of being between 0 and 255.`
+
+	formats["rust"] = func(c cpick.ColorValues, args []string) (string, error) {
+		open, close := "[", "]"
+		values := []string{fmt.Sprint(c.RGB.R), fmt.Sprint(c.RGB.G), fmt.Sprint(c.RGB.B)}
+
+		for _, arg := range args {
+			switch arg {
+			case "array", "u8":
+			case "tuple":
+				open, close = "(", ")"
+			case "f32":
+				values = []string{fmt.Sprintf("%.3f", float64(c.RGB.R)/255), fmt.Sprintf("%.3f", float64(c.RGB.G)/255), fmt.Sprintf("%.3f", float64(c.RGB.B)/255)}
+			default:
+				return "", fmt.Errorf("Invalid rust form %q, expected array, tuple, u8, or f32", arg)
+			}
+		}
+
+		return open + strings.Join(values, ", ") + close + "\n", nil
+	}
+
+	x.Method = func(args []string) error {
+		return run("rust", args)
+	}
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/ethanbaker/cpick"
+	"github.com/rwxrob/cmdtab"
+)
+
+func init() {
+	x := cmdtab.New("scss")
+
+	x.Usage = "<name>"
+	x.Summary = "Return a SCSS variable declaration with the color in hexadecimal format"
+
+	x.Description = `
+	The *scss* subcommand is used to return a SCSS variable
+	declaration for a color that is selected when cpick is
+	running. The name parameter passed into the command is the
+	name of the variable. If no name is specified, the variable
+	name is "custom".`
+
+	formats["scss"] = func(c cpick.ColorValues, args []string) (string, error) {
+		name := "custom"
+		if len(args) > 0 {
+			name = args[0]
+		}
+
+		return fmt.Sprintf("$%v: #%v;\n", name, c.Hex), nil
+	}
+
+	x.Method = func(args []string) error {
+		return run("scss", args)
+	}
+}
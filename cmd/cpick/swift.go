@@ -0,0 +1,29 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/ethanbaker/cpick"
+	"github.com/rwxrob/cmdtab"
+)
+
+func init() {
+	x := cmdtab.New("swift")
+
+	x.Usage = ""
+	x.Summary = "Return a UIColor initializer with the color"
+
+	x.Description = `
+	The *swift* subcommand is used to return a UIColor initializer
+	for a color that is selected when cpick is running. Each value
+	is written between 0 and 1 with three decimals, and the alpha
+	can be changed on the saturation-value table.`
+
+	formats["swift"] = func(c cpick.ColorValues, args []string) (string, error) {
+		return fmt.Sprintf("UIColor(red: %.3f, green: %.3f, blue: %.3f, alpha: %.3f)\n", float64(c.RGB.R)/255, float64(c.RGB.G)/255, float64(c.RGB.B)/255, c.Alpha), nil
+	}
+
+	x.Method = func(args []string) error {
+		return run("swift", args)
+	}
+}
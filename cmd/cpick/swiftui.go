@@ -0,0 +1,28 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/ethanbaker/cpick"
+	"github.com/rwxrob/cmdtab"
+)
+
+func init() {
+	x := cmdtab.New("swiftui")
+
+	x.Usage = ""
+	x.Summary = "Return a SwiftUI Color initializer with the color"
+
+	x.Description = `
+	The *swiftui* subcommand is used to return a SwiftUI Color
+	initializer for a color that is selected when cpick is running.
+	Each value is written between 0 and 1 with three decimals.`
+
+	formats["swiftui"] = func(c cpick.ColorValues, args []string) (string, error) {
+		return fmt.Sprintf("Color(red: %.3f, green: %.3f, blue: %.3f)\n", float64(c.RGB.R)/255, float64(c.RGB.G)/255, float64(c.RGB.B)/255), nil
+	}
+
+	x.Method = func(args []string) error {
+		return run("swiftui", args)
+	}
+}
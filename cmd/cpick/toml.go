@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/ethanbaker/cpick"
+	"github.com/rwxrob/cmdtab"
+)
+
+func init() {
+	x := cmdtab.New("toml")
+
+	x.Usage = ""
+	x.Summary = "Return a toml document containing all of the color info"
+
+	x.Description = `
+	The *toml* subcommand is used to return the corresponding toml
+	document for a color that is selected when cpick is running. The
+	document has the same keys as the *json* subcommand.`
+
+	formats["toml"] = func(c cpick.ColorValues, args []string) (string, error) {
+		var keys, tables strings.Builder
+
+		// Keys have to come before the tables in toml
+		v := reflect.ValueOf(c)
+		for i := 0; i < v.NumField(); i++ {
+			name := key(v.Type().Field(i))
+			field := v.Field(i)
+
+			if field.Kind() != reflect.Struct {
+				fmt.Fprintf(&keys, "%v = %v\n", name, scalar(field))
+				continue
+			}
+
+			fmt.Fprintf(&tables, "\n[%v]\n", name)
+			for j := 0; j < field.NumField(); j++ {
+				fmt.Fprintf(&tables, "%v = %v\n", strings.ToLower(field.Type().Field(j).Name), scalar(field.Field(j)))
+			}
+		}
+
+		return keys.String() + tables.String(), nil
+	}
+
+	x.Method = func(args []string) error {
+		return run("toml", args)
+	}
+}
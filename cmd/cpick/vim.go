@@ -0,0 +1,42 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/ethanbaker/cpick"
+	"github.com/rwxrob/cmdtab"
+)
+
+func init() {
+	x := cmdtab.New("vim")
+
+	x.Usage = "[foreground|background]"
+	x.Summary = "Return the arguments of a Vim highlight command with the color"
+
+	x.Description = `
+	The *vim* subcommand is used to return the arguments of a
+	highlight command for a color that is selected when cpick is
+	running. The color is given as a hex value for the GUI and as the
+	index of the closest color in the 256 color terminal palette for
+	the terminal. If "background" is given, the color is used as the
+	background instead of the foreground.`
+
+	formats["vim"] = func(c cpick.ColorValues, args []string) (string, error) {
+		suffix := "fg"
+		if len(args) > 0 {
+			switch args[0] {
+			case "foreground":
+			case "background":
+				suffix = "bg"
+			default:
+				return "", fmt.Errorf("Invalid vim attribute %q, expected foreground or background", args[0])
+			}
+		}
+
+		return fmt.Sprintf("gui%v=#%v cterm%v=%v\n", suffix, c.Hex, suffix, c.ColorIndex), nil
+	}
+
+	x.Method = func(args []string) error {
+		return run("vim", args)
+	}
+}
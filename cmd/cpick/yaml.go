@@ -0,0 +1,81 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/ethanbaker/cpick"
+	"github.com/rwxrob/cmdtab"
+)
+
+func init() {
+	x := cmdtab.New("yaml")
+
+	x.Usage = ""
+	x.Summary = "Return a yaml document containing all of the color info"
+
+	x.Description = `
+	The *yaml* subcommand is used to return the corresponding yaml
+	document for a color that is selected when cpick is running. The
+	document has the same keys as the *json* subcommand.`
+
+	formats["yaml"] = func(c cpick.ColorValues, args []string) (string, error) {
+		var b strings.Builder
+
+		v := reflect.ValueOf(c)
+		for i := 0; i < v.NumField(); i++ {
+			name := key(v.Type().Field(i))
+			field := v.Field(i)
+
+			if field.Kind() != reflect.Struct {
+				fmt.Fprintf(&b, "%v: %v\n", name, scalar(field))
+				continue
+			}
+
+			fmt.Fprintf(&b, "%v:\n", name)
+			for j := 0; j < field.NumField(); j++ {
+				fmt.Fprintf(&b, "  %v: %v\n", strings.ToLower(field.Type().Field(j).Name), scalar(field.Field(j)))
+			}
+		}
+
+		return b.String(), nil
+	}
+
+	x.Method = func(args []string) error {
+		return run("yaml", args)
+	}
+}
+
+// key returns the json key of a field of cpick.ColorValues, which the yaml
+// and toml types also use. The fields of the nested color types are written
+// in lowercase instead.
+func key(f reflect.StructField) string {
+	name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+	if name == "" {
+		return f.Name
+	}
+
+	return name
+}
+
+// scalar formats a value for the yaml and toml types. Strings are quoted
+// the same way as json, which both formats accept, and floats always have
+// a decimal point.
+func scalar(v reflect.Value) string {
+	switch v.Kind() {
+	case reflect.String:
+		s, _ := json.Marshal(v.String())
+		return string(s)
+	case reflect.Float32, reflect.Float64:
+		s := strconv.FormatFloat(v.Float(), 'f', -1, 64)
+		if !strings.Contains(s, ".") {
+			s += ".0"
+		}
+		return s
+	}
+
+	return fmt.Sprint(v.Interface())
+}
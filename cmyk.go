@@ -0,0 +1,172 @@
+package cpick
+
+import (
+	"fmt"
+	"math"
+
+	color "github.com/ethanbaker/colors"
+	"github.com/ethanbaker/cpick/cview"
+	"github.com/gdamore/tcell/v2"
+)
+
+// Most printing presses can't put down more than this much ink in total
+const CMYK_INK_LIMIT = 300
+
+// The color of the paper the printed swatch is shown on, which is a slightly
+// warm off-white like most uncoated paper
+var cmykPaper = color.RGB{R: 245, G: 242, B: 234}
+
+// CMYK Handlers ----------------------------------------------------------
+
+func (p *Picker) cmykChangedFunc(value int) {
+	cmyk := p.cmykColor()
+	hsv := color.CMYKtoHSV(cmyk)
+	p.setColorValues(hsv, p.cmykBlock, p.cmykText, hsv, p.cmykBlock, p.cmykText)
+	p.setCMYKSwatch(cmyk)
+}
+
+// Select the color of the sliders when enter is pressed on one of them
+func (p *Picker) cmykInputCapture(event *tcell.EventKey) *tcell.EventKey {
+	if event.Key() == tcell.KeyEnter {
+		p.selectCMYKColor()
+		return nil
+	}
+
+	return event
+}
+
+// Show the CMYK sliders at the highlighted color, or go back if they are
+// already shown
+func (p *Picker) toggleCMYK() {
+	if p.cmykForm.HasFocus() {
+		p.hideCMYK()
+		return
+	}
+
+	if hsv, ok := p.getCurrentColor(); ok {
+		p.setCMYKColor(color.HSVtoCMYK(hsv))
+	}
+
+	p.cmykReturnPage, _ = p.pages.GetFrontPage()
+	p.cmykFocus = p.app.GetFocus()
+
+	p.pages.SwitchToPage("CMYK page")
+	p.cmykForm.SetFocus(0)
+	p.app.SetFocus(p.cmykForm)
+}
+
+func (p *Picker) hideCMYK() {
+	p.pages.SwitchToPage(p.cmykReturnPage)
+	p.app.SetFocus(p.cmykFocus)
+}
+
+// CMYK setup -------------------------------------------------------------
+
+func (p *Picker) cmykSetup() {
+	for _, s := range []*cview.Slider{p.cSlider, p.mSlider, p.ySlider, p.kSlider} {
+		s.SetMax(100)
+		s.SetIncrement(1)
+		s.SetChangedFunc(p.cmykChangedFunc)
+		s.SetInputCapture(p.cmykInputCapture)
+	}
+
+	p.cSlider.SetLabel("Cyan (0-100)")
+	p.mSlider.SetLabel("Magenta (0-100)")
+	p.ySlider.SetLabel("Yellow (0-100)")
+	p.kSlider.SetLabel("Black (0-100)")
+
+	// The setup runs every time the application starts, so remove the
+	// sliders that were already added
+	p.cmykForm.Clear(true)
+	p.cmykForm.AddFormItem(p.cSlider)
+	p.cmykForm.AddFormItem(p.mSlider)
+	p.cmykForm.AddFormItem(p.ySlider)
+	p.cmykForm.AddFormItem(p.kSlider)
+	p.cmykForm.AddButton("Select", p.selectCMYKColor)
+	p.cmykForm.SetCancelFunc(p.hideCMYK)
+
+	p.cmykBlock.SetText(colorBlockWide)
+	p.cmykBlock.SetScrollBarVisibility(cview.ScrollBarNever)
+	p.cmykText.SetScrollBarVisibility(cview.ScrollBarNever)
+	p.cmykText.SetDynamicColors(true)
+	p.cmykSwatch.SetScrollBarVisibility(cview.ScrollBarNever)
+	p.cmykSwatch.SetDynamicColors(true)
+
+	title := cview.NewTextView()
+	title.SetTextAlign(cview.AlignCenter)
+	title.SetText("Adjust the cyan, magenta, yellow, and black of the color for print")
+
+	help := cview.NewTextView()
+	help.SetTextAlign(cview.AlignRight)
+	help.SetText("Press h/l to move a slider, tab to switch sliders, enter to select the color, and K or escape to go back")
+
+	colorFlex := cview.NewFlex()
+	colorFlex.SetDirection(cview.FlexColumn)
+	colorFlex.AddItem(p.cmykBlock, 0, 1, false)
+	colorFlex.AddItem(p.cmykSwatch, 0, 1, false)
+	colorFlex.AddItem(p.cmykText, 0, 2, false)
+
+	p.cmykFlex.SetDirection(cview.FlexRow)
+	p.cmykFlex.AddItem(title, 1, 0, false)
+	p.cmykFlex.AddItem(p.cmykForm, 11, 0, true)
+	p.cmykFlex.AddItem(colorFlex, 0, 1, false)
+	p.cmykFlex.AddItem(help, 1, 0, false)
+
+	p.setCMYKColor(color.CMYK{C: 0, M: 100, Y: 100, K: 0})
+}
+
+// Helper functions -------------------------------------------------------
+
+// Get the color of the CMYK sliders
+func (p *Picker) cmykColor() color.CMYK {
+	return color.CMYK{C: p.cSlider.GetProgress(), M: p.mSlider.GetProgress(), Y: p.ySlider.GetProgress(), K: p.kSlider.GetProgress()}
+}
+
+// Move the CMYK sliders to the given color
+func (p *Picker) setCMYKColor(cmyk color.CMYK) {
+	p.cSlider.SetProgress(cmyk.C)
+	p.mSlider.SetProgress(cmyk.M)
+	p.ySlider.SetProgress(cmyk.Y)
+	p.kSlider.SetProgress(cmyk.K)
+	p.cmykChangedFunc(0)
+}
+
+// Get an approximation of how a CMYK color looks printed on the paper, by
+// multiplying the color of the inks with the color of the paper
+func printedRGB(cmyk color.CMYK) color.RGB {
+	ink := color.CMYKtoRGB(cmyk)
+	multiply := func(a, b int) int {
+		return int(math.Round(float64(a) * float64(b) / 255))
+	}
+
+	return color.RGB{R: multiply(ink.R, cmykPaper.R), G: multiply(ink.G, cmykPaper.G), B: multiply(ink.B, cmykPaper.B)}
+}
+
+// Update the printed swatch and the total amount of ink of a CMYK color
+func (p *Picker) setCMYKSwatch(cmyk color.CMYK) {
+	paper := color.RGBtoHex(p.simulateRGB(cmykPaper))
+	printed := color.RGBtoHex(p.simulateRGB(printedRGB(cmyk)))
+
+	ink := cmyk.C + cmyk.M + cmyk.Y + cmyk.K
+	inkText := fmt.Sprintf("Total ink: %v%%", ink)
+	if ink > CMYK_INK_LIMIT {
+		inkText = fmt.Sprintf("[red]Total ink: %v%% (over %v%%)[-]", ink, CMYK_INK_LIMIT)
+	}
+
+	text := "\n  Printed swatch\n"
+	for i := 0; i < 4; i++ {
+		text += fmt.Sprintf("  [:#%v]  [:#%v]            [:#%v]  [:-]\n", paper, printed, paper)
+	}
+	p.cmykSwatch.SetText(text + "\n  " + inkText)
+}
+
+// Select the color of the CMYK sliders as the final color, keeping the exact
+// CMYK values
+func (p *Picker) selectCMYKColor() {
+	cmyk := p.cmykColor()
+	hsv := color.CMYKtoHSV(cmyk)
+
+	c := newColorValues(hsv, p.getColorName(hsv, hsv), p.alpha)
+	c.CMYK = cmyk
+	p.selectColorValues(c)
+}
@@ -0,0 +1,70 @@
+package cpick
+
+import (
+	"os"
+	"strings"
+
+	color "github.com/ethanbaker/colors"
+	"github.com/gdamore/tcell/v2"
+)
+
+// Names of the ways colors can be drawn: as 24-bit truecolor, or as the
+// nearest of the 256 terminal colors for terminals that don't draw truecolor
+// properly. The first name is the default.
+var colorModeNames = [...]string{"truecolor", "256"}
+
+// Get the index of a color mode by name. Unknown names use truecolor.
+func getColorMode(name string) int {
+	for i, v := range colorModeNames {
+		if v == name {
+			return i
+		}
+	}
+
+	return 0
+}
+
+// Switch between drawing colors in truecolor and in 256 colors. Only how the
+// colors are drawn changes, not the returned color values.
+func (p *Picker) toggleColorMode() {
+	p.colorMode = (p.colorMode + 1) % len(colorModeNames)
+
+	p.setSVCursor()
+	p.redrawColors()
+
+	if p.colorMode == 0 {
+		p.setStatus("Drawing colors in truecolor", false)
+	} else {
+		p.setStatus("Drawing colors with the nearest of the 256 terminal colors", false)
+	}
+}
+
+// Get the tcell color of an RGB value in the color mode
+func (p *Picker) renderColor(rgb color.RGB) tcell.Color {
+	if colorModeNames[p.colorMode] == "256" {
+		return tcell.PaletteColor(nearestAnsi256(rgb))
+	}
+
+	return tcell.NewRGBColor(int32(rgb.R), int32(rgb.G), int32(rgb.B))
+}
+
+// Check whether a screen draws truecolor, either because the terminal says so
+// through COLORTERM or because tcell found truecolor in its capabilities
+func supportsTruecolor(screen tcell.Screen) bool {
+	switch strings.ToLower(os.Getenv("COLORTERM")) {
+	case "truecolor", "24bit":
+		return true
+	}
+
+	return screen.Colors() >= 1<<24
+}
+
+// Suggest the 256 color mode once if colors are drawn in truecolor on a
+// terminal that doesn't seem to draw truecolor
+func (p *Picker) warnTruecolor() {
+	if p.truecolorTerminal || colorModeNames[p.colorMode] != "truecolor" {
+		return
+	}
+
+	p.setStatus("This terminal may not draw truecolor, press M or use -color-mode 256 if the colors look wrong", false)
+}
@@ -1,20 +1,50 @@
 package cpick
 
+import (
+	"strconv"
+	"strings"
+
+	color "github.com/ethanbaker/colors"
+)
+
 // jsonColor type used to import specific colors in RawData
 type jsonColor struct {
-	NAME  string
-	VALUE string
+	NAME  string `json:"name"`
+	VALUE string `json:"value"`
+
+	// The RGB and HSV values of the color, worked out from the hex value
+	// when the colors are read
+	RGB color.RGB `json:"-"`
+	HSV color.HSV `json:"-"`
 }
 
 // jsonColorType type used to import color types from RawData
 type jsonColorType struct {
-	NAME   string
-	COLORS []jsonColor
+	NAME   string      `json:"name"`
+	COLORS []jsonColor `json:"colors"`
 }
 
 // jsonData type used to import all data in RawData
 type jsonData struct {
-	COLORLIST []jsonColorType
+	COLORLIST []jsonColorType `json:"colorList"`
+}
+
+// Work out the RGB and HSV values of every color from its hex value. Colors
+// without a valid hex value are left black.
+func computeColors(data *jsonData) {
+	for i := range data.COLORLIST {
+		for j := range data.COLORLIST[i].COLORS {
+			c := &data.COLORLIST[i].COLORS[j]
+
+			hex := strings.TrimPrefix(c.VALUE, "#")
+			if _, err := strconv.ParseUint(hex, 16, 32); err != nil || len(hex) != 6 {
+				continue
+			}
+
+			c.RGB = color.HextoRGB(color.Hex(hex))
+			c.HSV = color.RGBtoHSV(c.RGB)
+		}
+	}
 }
 
 // preset color data that will be used if no other data is provided
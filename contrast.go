@@ -0,0 +1,119 @@
+package cpick
+
+import (
+	"fmt"
+	"math"
+
+	color "github.com/ethanbaker/colors"
+)
+
+// Minimum WCAG contrast ratios for normal text
+const (
+	CONTRAST_AA  = 4.5
+	CONTRAST_AAA = 7.0
+)
+
+// Hex values of the backgrounds that the sample text is shown on
+var sampleBackgrounds = [...]color.Hex{"ffffff", "000000", "808080"}
+
+// Get the relative luminance of an RGB value as defined by WCAG 2
+func relativeLuminance(rgb color.RGB) float64 {
+	channel := func(c int) float64 {
+		v := float64(c) / 255
+		if v <= 0.03928 {
+			return v / 12.92
+		}
+		return math.Pow((v+0.055)/1.055, 2.4)
+	}
+
+	return 0.2126*channel(rgb.R) + 0.7152*channel(rgb.G) + 0.0722*channel(rgb.B)
+}
+
+// Get the WCAG contrast ratio between two RGB values (from 1 to 21)
+func contrastRatio(a color.RGB, b color.RGB) float64 {
+	la := relativeLuminance(a)
+	lb := relativeLuminance(b)
+
+	if la < lb {
+		la, lb = lb, la
+	}
+
+	return (la + 0.05) / (lb + 0.05)
+}
+
+// Get the text color (black or white) with the highest contrast ratio on a
+// background color
+func bestTextColor(bg color.RGB) color.RGB {
+	black := color.RGB{R: 0, G: 0, B: 0}
+	white := color.RGB{R: 255, G: 255, B: 255}
+
+	if contrastRatio(black, bg) >= contrastRatio(white, bg) {
+		return black
+	}
+	return white
+}
+
+// SuggestTextColor function returns the text color (black or white) with the
+// highest WCAG contrast ratio on a background color, along with the ratio
+func SuggestTextColor(bg ColorValues) (ColorValues, float64) {
+	text := bestTextColor(bg.RGB)
+	return newRGBColorValues(text, filePresetColors("")), contrastRatio(text, bg.RGB)
+}
+
+// Set the reference color used for the contrast ratio
+func (p *Picker) setContrastReference(rgb color.RGB) {
+	p.contrastReference = rgb
+	p.hasContrastReference = true
+}
+
+// Remove the reference color and hide the contrast ratio
+func (p *Picker) clearContrastReference() {
+	p.hasContrastReference = false
+	p.contrastText.SetText("")
+}
+
+// Update the contrast text with the ratio between an RGB value and the
+// reference color
+func (p *Picker) setContrastText(rgb color.RGB) {
+	if !p.hasContrastReference {
+		p.contrastText.SetText("")
+		return
+	}
+
+	ratio := contrastRatio(rgb, p.contrastReference)
+
+	pass := func(min float64) string {
+		if ratio >= min {
+			return "[green]Pass[-]"
+		}
+		return "[red]Fail[-]"
+	}
+
+	p.contrastText.SetText(fmt.Sprintf("Contrast vs #%v: %.2f:1\nAA: %v  AAA: %v", color.RGBtoHex(p.contrastReference), ratio, pass(CONTRAST_AA), pass(CONTRAST_AAA)))
+}
+
+// Update the best text with the text color that stands out the most on a
+// color, shown on the color along with the contrast ratio
+func (p *Picker) setBestText(rgb color.RGB) {
+	text := bestTextColor(rgb)
+	ratio := contrastRatio(text, rgb)
+
+	fg := color.RGBtoHex(p.simulateRGB(text))
+	bg := color.RGBtoHex(p.simulateRGB(rgb))
+	p.bestText.SetText(fmt.Sprintf("Best text: [#%v:#%v] Aa [-:-] #%v %.2f:1", fg, bg, color.RGBtoHex(text), ratio))
+}
+
+// Update the sample text with a color used as the foreground on the sample
+// backgrounds, and as the background behind the sample colors
+func (p *Picker) setSampleText(rgb color.RGB) {
+	hex := color.RGBtoHex(p.simulateRGB(rgb))
+
+	var fg, bg string
+	for _, v := range sampleBackgrounds {
+		sample := color.RGBtoHex(p.simulateRGB(color.HextoRGB(v)))
+		fg += fmt.Sprintf(" [#%v:#%v] Sample [-:-]", hex, sample)
+		bg += fmt.Sprintf(" [#%v:#%v] Sample [-:-]", sample, hex)
+	}
+
+	p.sampleText.SetText(fg + "\n" + bg)
+}
@@ -3,15 +3,14 @@ package cpick
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io/ioutil"
-	"log"
 	"math"
 	"os"
-	"os/user"
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 
 	color "github.com/ethanbaker/colors"
 	"github.com/ethanbaker/cpick/cview"
@@ -22,10 +21,14 @@ import (
 const BREAKPOINT_HEIGHT = 30
 const BREAKPOINT_WIDTH = 110
 
-// Global configuration variables
-var testingMode = false
-var smallWidth = false
-var smallHeight = false
+// GenerateNames makes colors that aren't preset colors get a generated name
+// from the closest preset color and the hex value (EX: "darkorange-ff8800")
+// instead of "custom color"
+var GenerateNames = false
+
+// ErrCanceled is returned when the application is quit without a color being
+// selected
+var ErrCanceled = errors.New("no color was selected")
 
 // jsonColorInfo type used to hold imported colors
 type jsonColorInfo struct {
@@ -33,18 +36,188 @@ type jsonColorInfo struct {
 	length int
 	colors []jsonColor
 	table  *cview.Table
+
+	// All of the colors of the list in their original order, and the index
+	// of the sort mode used to lay them out
+	all  []jsonColor
+	sort int
+
+	// Whether the cells of the table have been drawn since the colors were
+	// laid out
+	drawn bool
 }
 
-// ColorValues type used to hold color values and optional name
+// ColorValues type used to hold color values and optional name. In json, the
+// keys are lowercase and the nested color types use lowercase keys too (EX:
+// {"rgb": {"r": 255, "g": 0, "b": 0}, ...}).
 type ColorValues struct {
-	RGB     color.RGB
-	HSV     color.HSV
-	HSL     color.HSL
-	CMYK    color.CMYK
-	Hex     color.Hex
-	Decimal color.Decimal
-	Ansi    color.Ansi
-	Name    string
+	RGB     color.RGB     `json:"rgb"`
+	HSV     color.HSV     `json:"hsv"`
+	HSL     color.HSL     `json:"hsl"`
+	HWB     HWB           `json:"hwb"`
+	CMYK    color.CMYK    `json:"cmyk"`
+	Hex     color.Hex     `json:"hex"`
+	Decimal color.Decimal `json:"decimal"`
+	Ansi    color.Ansi    `json:"ansi"`
+	Name    string        `json:"name"`
+	Alpha   float64       `json:"alpha"`
+
+	// ColorIndex is the index of the color in the 256 color palette, or of
+	// the closest color in the palette if the color isn't in it
+	ColorIndex int `json:"colorIndex"`
+}
+
+// The color types of the colors package use uppercase json keys, so they are
+// converted to these types to give every key of ColorValues the same case
+type jsonRGB struct {
+	R int `json:"r"`
+	G int `json:"g"`
+	B int `json:"b"`
+}
+
+type jsonHSV struct {
+	H int `json:"h"`
+	S int `json:"s"`
+	V int `json:"v"`
+}
+
+type jsonHSL struct {
+	H int `json:"h"`
+	S int `json:"s"`
+	L int `json:"l"`
+}
+
+type jsonCMYK struct {
+	C int `json:"c"`
+	M int `json:"m"`
+	Y int `json:"y"`
+	K int `json:"k"`
+}
+
+// jsonColorValues has the same keys as ColorValues, using the lowercase
+// color types
+type jsonColorValues struct {
+	RGB        jsonRGB       `json:"rgb"`
+	HSV        jsonHSV       `json:"hsv"`
+	HSL        jsonHSL       `json:"hsl"`
+	HWB        HWB           `json:"hwb"`
+	CMYK       jsonCMYK      `json:"cmyk"`
+	Hex        color.Hex     `json:"hex"`
+	Decimal    color.Decimal `json:"decimal"`
+	Ansi       color.Ansi    `json:"ansi"`
+	Name       string        `json:"name"`
+	Alpha      float64       `json:"alpha"`
+	ColorIndex int           `json:"colorIndex"`
+}
+
+// MarshalJSON writes the color values with lowercase keys, including the keys
+// of the nested color types
+func (c ColorValues) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonColorValues{
+		jsonRGB(c.RGB), jsonHSV(c.HSV), jsonHSL(c.HSL), c.HWB, jsonCMYK(c.CMYK),
+		c.Hex, c.Decimal, c.Ansi, c.Name, c.Alpha, c.ColorIndex,
+	})
+}
+
+// UnmarshalJSON reads color values written by MarshalJSON
+func (c *ColorValues) UnmarshalJSON(data []byte) error {
+	var v jsonColorValues
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	*c = ColorValues{
+		color.RGB(v.RGB), color.HSV(v.HSV), color.HSL(v.HSL), v.HWB, color.CMYK(v.CMYK),
+		v.Hex, v.Decimal, v.Ansi, v.Name, v.Alpha, v.ColorIndex,
+	}
+
+	return nil
+}
+
+// HWB is a color in hue (0-359), whiteness (0-100), and blackness (0-100),
+// which is used by CSS
+type HWB struct {
+	H int `json:"h"`
+	W int `json:"w"`
+	B int `json:"b"`
+}
+
+// HSVtoHWB converts an HSV color to HWB. The blackness is the amount that the
+// value is below 100, and the whiteness is the amount of the value that isn't
+// saturated.
+func HSVtoHWB(hsv color.HSV) HWB {
+	w := int(math.Round(float64((100-hsv.S)*hsv.V) / 100))
+	return HWB{H: hsv.H, W: w, B: 100 - hsv.V}
+}
+
+// The lowest and highest color temperatures (in Kelvin) that can be converted
+const (
+	MIN_KELVIN = 1000
+	MAX_KELVIN = 40000
+)
+
+// KelvintoRGB converts a color temperature (in Kelvin, kept between 1000 and
+// 40000) to the RGB value of a blackbody at that temperature. The conversion
+// uses Tanner Helland's curve fit of blackbody data, which is within a few
+// values of each channel of the data, but is only an approximation: it
+// doesn't account for the white point of a display, and the colors below
+// 1900K and above 10000K are less accurate.
+func KelvintoRGB(kelvin int) color.RGB {
+	kelvin = int(math.Max(MIN_KELVIN, math.Min(MAX_KELVIN, float64(kelvin))))
+	t := float64(kelvin) / 100
+
+	var r, g, b float64
+	if t <= 66 {
+		r = 255
+		g = 99.4708025861*math.Log(t) - 161.1195681661
+	} else {
+		r = 329.698727446 * math.Pow(t-60, -0.1332047592)
+		g = 288.1221695283 * math.Pow(t-60, -0.0755148492)
+	}
+
+	switch {
+	case t >= 66:
+		b = 255
+	case t <= 19:
+		b = 0
+	default:
+		b = 138.5177312231*math.Log(t-10) - 305.0447927307
+	}
+
+	channel := func(v float64) int {
+		return int(math.Round(math.Max(0, math.Min(255, v))))
+	}
+
+	return color.RGB{R: channel(r), G: channel(g), B: channel(b)}
+}
+
+// RGBtoKelvin finds the color temperature (in Kelvin, rounded to 100K) whose
+// blackbody color is closest to an RGB value. Only the balance of the
+// channels is compared, so a color and its darker shades have the same
+// temperature. Colors that aren't close to a blackbody color (such as
+// greens and purples) still get the nearest temperature, which doesn't
+// describe them well.
+func RGBtoKelvin(rgb color.RGB) int {
+	// Scale a color so that its brightest channel is 255
+	scale := func(c color.RGB) (float64, float64, float64) {
+		m := math.Max(float64(c.R), math.Max(float64(c.G), float64(c.B)))
+		if m == 0 {
+			return 255, 255, 255
+		}
+		return float64(c.R) * 255 / m, float64(c.G) * 255 / m, float64(c.B) * 255 / m
+	}
+
+	r, g, b := scale(rgb)
+
+	nearest, distance := MIN_KELVIN, math.Inf(1)
+	for k := MIN_KELVIN; k <= MAX_KELVIN; k += 100 {
+		kr, kg, kb := scale(KelvintoRGB(k))
+		if d := (r-kr)*(r-kr) + (g-kg)*(g-kg) + (b-kb)*(b-kb); d < distance {
+			nearest, distance = k, d
+		}
+	}
+
+	return nearest
 }
 
 var colorBlockWide string = `
@@ -58,344 +231,625 @@ var colorBlockSmall string = `
   ████████████
 `
 
-var colorTextWide string = `
-  RGB: %v, %v, %v
+// The lines of the color values in the order they are shown, where the small
+// lines are used on screens narrower or shorter than the breakpoint size
+// (BREAKPOINT_WIDTH x BREAKPOINT_HEIGHT)
+var colorTextWide = []string{
+	"RGB: %v, %v, %v",
+	"HSV: %v°, %v%%, %v%%",
+	"HSL: %v°, %v%%, %v%%",
+	"HWB: %v°, %v%%, %v%%",
+	"CMYK: %v%%, %v%%, %v%%, %v%%",
+	"Hex: #%v",
+	"Decimal: %v",
+	`Ansi: "\033%v"`,
+	"Alpha: %v",
+}
+
+var colorTextSmall = []string{
+	"RGB: %v,%v,%v",
+	"HSV: %v°,%v%%,%v%%",
+	"HSL: %v°,%v%%,%v%%",
+	"HWB: %v°,%v%%,%v%%",
+	"CMYK: %v%%,%v%%\n\t  %v%%,%v%%",
+	"Hex: #%v",
+	"Decimal: %v",
+	"Ansi:\n\"\\033%v\"",
+	"Alpha: %v",
+}
 
-  HSV: %v°, %v%%, %v%%
+var colorPageText string = "██████████  %v    %v  "
 
-  HSL: %v°, %v%%, %v%%
+// The help text, where the keys of each action are written as the action name
+// in braces (EX: "{quit}") and filled in by helpText
+var helpString string = `
+Movement: vim keys (h,j,k,l) or arrow keys
 
-  CMYK: %v%%, %v%%, %v%%, %v%%
 
-  Hex: #%v
+Moving with the mouse: click or drag on the hue and saturation-value tables
+	- Click a preset color to create a new saturation-value table
 
-  Decimal: %v
 
-  Ansi: "\033%v"
-`
+Quitting the application: {quit}
 
-var colorTextSmall string = `
-RGB: %v,%v,%v
 
-HSV: %v°,%v%%,%v%%
+Showing this help screen: {help}
 
-HSL: %v°,%v%%,%v%%
 
-CMYK: %v%%,%v%%
-	  %v%%,%v%%
+Copying the highlighted color to the clipboard: {copy}
 
-Hex: #%v
 
-Decimal: %v
+Copying the truecolor escape sequence of the highlighted color to the clipboard: {copy-escape}
+	- The escape sequence (EX: \033[38;2;255;0;0m) can be pasted into a shell to test the color
 
-Ansi:
-"\033%v"
-`
 
-var colorPageText string = "██████████  %v    %v  "
+Showing the color harmonies of the highlighted color: {harmonies}
+
+
+Simulating color blindness (protanopia, deuteranopia, tritanopia, off): {simulate}
+
+
+Switching to grayscale mode (only grays on the saturation-value table): {grayscale}
+
+
+Jumping to a random color on the saturation-value table: {random}
 
-var helpString string = `
-Movement: vim keys (h,j,k,l) or arrow keys
 
+Switching between drawing colors in truecolor and in 256 colors: {color-mode}
+	- Use 256 colors if the colors look wrong because the terminal doesn't draw truecolor properly
 
-Quitting the application: escape or q
+	- Only how the colors are drawn changes, not the selected color
+
+
+Undoing and redoing selections of the saturation-value table: {undo} and {redo}
+
+
+Switching between the top and bottom half of the selected cell of the saturation-value table: {sv-half}
+	- Each cell shows two values, and the cursor only covers the half that is selected
+
+
+Reloading the preset colors from colors.json: {reload}
+
+
+Switching between the saturation-value table and the shades and tints: {ramp}
+	- Press enter to select a shade or tint as the final color
+
+
+Showing larger color blocks: {large-preview}
+
+
+Showing a color type in bold at the top of the color values: {emphasis}
+	- Cycles through RGB, HSV, HSL, HWB, CMYK, hex, decimal, ansi, and no emphasis
+
+
+Picking a color from the 256 color terminal palette: {palette-256}
+	- Press enter to select a color and {palette-256} or escape to go back
+
+
+Picking one of the 16 standard terminal colors: {palette-16}
+	- Press enter to select a color and {palette-16} or escape to go back
+
+
+Adjusting the hue, saturation, and value of the highlighted color with sliders: {sliders}
+	- Press h/l to move a slider and tab to switch sliders
+
+	- Press enter to select the color and {sliders} or escape to go back
+
+
+Making a gradient between two colors: {gradient}
+	- Press {gradient} on the start color and then on the end color to show the gradient
+
+	- Press {gradient-more} and {gradient-fewer} to change the number of colors in the gradient
+
+	- Press enter to select a color and {gradient} or escape to go back
+
+
+Adjusting the cyan, magenta, yellow, and black of the highlighted color for print: {cmyk}
+	- Press h/l to move a slider and tab to switch sliders
+
+	- A swatch shows how the color looks printed, along with the total amount of ink
+
+	- Press enter to select the color and {cmyk} or escape to go back
+
+
+Adding the highlighted color to the favorites: {add-favorite}
+
+
+Showing the favorite colors: {favorites}
+	- Press enter to select a color, {remove-favorite} to remove a color, and {favorites} or escape to go back
+
+
+Picking from an alphabetical list of every named preset color: {named-list}
+	- Press enter to select a color and {named-list} or escape to go back
+
+
+Naming the highlighted color and saving it to colors.json: {save}
+	- Type a name and press enter to save the color, or press escape to go back
+
+	- The color is added to the "custom" color list and its page is shown right away
+
+
+Typing a hex value into the field on the hue screen: {hex-input}
+	- Press enter to go to the saturation-value table at the typed color
+
+	- Press escape to go back
+
+
+Jumping to a hue (0-359) on the hue table: {hue-input}
+	- Press enter to move the hue table to the typed hue
+
+	- Press escape to go back
 
 
 While on the hue table:
 	- Press enter to create a new saturation-value table
 
-	- Press space to select the preset color table
+	- Press {switch-presets} to select the preset color table
 
-	- Press tab to switch to the saturation-value table
+	- Press {switch-table} to switch to the saturation-value table
 
 
 While on the preset color table:
 	- Press enter to create a new saturation-value table
 
-	- Press space to select the hue table
+	- Press {switch-presets} to select the hue table
+
+	- Press {switch-table} to switch to the saturation-value table
+
+	- Press {next-page} to go to the next color page
+
+	- Press {prev-page} to go to the previous color page
+
+	- Type a number before {next-page} or {prev-page} to move that many pages
+
+	- Press {first-page} to go to the first color page and {last-page} to go to the last color page
+
+	- Press {sort} to sort the colors of the page by hue, lightness, or name
 
-	- Press tab to switch to the saturation-value table
+	- Press {filter} to filter the colors by name (enter keeps the filter and escape clears it)
 
-	- Press C to go to the next color page
+	- Press {search} to enter a search menu for colors
 
-	- Press c to go to the previous color page
+	- Press {next-match} to go to the next search instance
 
-	- Press ? to enter a search menu for colors
+	- Press {prev-match} to go to the previous search instance
 
-	- Press N to go to the next search instance
 
-	- Press n to go to the previous search instance
+While on the hue table or the preset color table:
+	- Press {history} to switch to the recently selected colors
+
+	- Press {history} again to switch back
+
+
+While on the recently selected colors:
+	- Press enter to select a color
+
+	- Press {switch-table} to switch to the saturation-value table
 
 
 While on the saturation-value table:
-	- Press enter to select the final color
+	- Press enter to select the final color (in sandbox mode, the color is noted below the page instead)
 
-	- Press tab to switch to the hue table
+	- Press {alpha-down} to decrease the alpha and {alpha-up} to increase the alpha
+
+	- Press {value-up} and {value-down} to change the value and {saturation-up} and {saturation-down} to change the saturation
+
+	- Press {switch-table} to switch to the hue table
 `
 
 var searchHelpString string = `
 	To search for a color name, type the name of the color into the search bar. Related colors will appear below.
-	Once a color (or phrase) is desired, press enter. You can press N (forward) and n (reverse) to swap between instances.
+	Once a color (or phrase) is desired, press enter. You can press {next-match} (forward) and {prev-match} (reverse) to swap between instances.
+	Names don't need to be exact, so small typos and missing spaces still match (EX: "ligth blue"), with the closest colors first.
+	Pasted text (EX: a hex value copied from elsewhere) is added to the search bar at once, with spaces and line breaks around it removed.
 
 	Each value type you want to select will have instructions below:
 
-		- Hexadecimal: type the hex value starting with "#" (EX: #ffffff)
+		- Hexadecimal: type the hex value, with or without the "#" (EX: #ffffff, ffffff, the shorthand fff, or #ffffffcc with an alpha). The closest preset colors can then be cycled through with {next-match} and {prev-match}.
 		- RGB: type "rgb:" and three RGB values separated by a space (EX: rgb: 255 255 255)
 		- HSV: type "hsv:" and three HSV values separated by a space (EX: hsv: 0 100 0)
 		- HSL: type "hsl:" and three HSL values separated by a space (EX: hsl: 0 100 50)
 		- CMYK: type "cmyk:" and four CMYK values separated by a space (EX: cmyk: 0 0 0 0)
 		- Decimal: type "decimal:" and then the decimal value (EX: 16777215)
+		- Color temperature: type "kelvin:" and then a temperature between 1000 and 40000 (EX: kelvin: 6500). The color is an approximation of a blackbody at that temperature.
+
+	To check the WCAG contrast ratio of colors against a background, type "bg:" and then a color value in any of
+	the formats above (EX: bg: #ffffff). The ratio will be shown on the Saturation-Value table. Type "bg:" alone to hide it.
 
 	Once a color is selected, you will be taken to the Saturation-Value table with the specified color selected.
 
 	Any errors that you make will appear in red below the search bar.
 `
 
-// Global variables to make up elements on screen
-var app *cview.Application = cview.NewApplication()
+// The largest count that can be typed before the next or previous page keys
+const MAX_PAGE_COUNT = 999
+
+// The amount that + and - change the value and [ and ] change the saturation
+// on the saturation-value table
+const DEFAULT_SV_STEP = 5
+
+// Input Handlers ---------------------------------------------------------
+
+func (p *Picker) inputCaptureHandler(event *tcell.EventKey) *tcell.EventKey {
+	if text, ok := pastedText(event); ok {
+		p.pasteText(text)
+		return nil
+	}
+
+	switch {
+	case p.eventMatches("quit", event):
+		if !p.typing() {
+			p.app.Stop()
+		}
+
+	case p.eventMatches("help", event):
+		p.showHelp()
 
-var pages *cview.Pages = cview.NewPages()
+	case p.eventMatches("search", event):
+		p.showSearch()
+		return nil
 
-var hFlex *cview.Flex = cview.NewFlex()
-var svFlex *cview.Flex = cview.NewFlex()
+	case p.eventMatches("switch-table", event):
+		if !p.typing() {
+			p.switchTable()
+		}
 
-var hTable *cview.Table = cview.NewTable()
-var svTable *cview.Table = cview.NewTable()
+	case p.eventMatches("copy", event):
+		if !p.typing() {
+			p.copyCurrentColor()
+		}
 
-var darkHBlock *cview.TextView = cview.NewTextView()
-var darkHText *cview.TextView = cview.NewTextView()
+	case p.eventMatches("copy-escape", event):
+		if !p.typing() {
+			p.copyCurrentEscape()
+		}
 
-var lightHBlock *cview.TextView = cview.NewTextView()
-var lightHText *cview.TextView = cview.NewTextView()
+	case p.eventMatches("history", event):
+		p.toggleHistory()
 
-var darkSVBlock *cview.TextView = cview.NewTextView()
-var darkSVText *cview.TextView = cview.NewTextView()
+	case p.eventMatches("harmonies", event):
+		if !p.typing() {
+			p.toggleHarmonies()
+		}
 
-var lightSVBlock *cview.TextView = cview.NewTextView()
-var lightSVText *cview.TextView = cview.NewTextView()
+	case p.eventMatches("simulate", event):
+		if !p.typing() {
+			p.toggleSimulation()
+		}
 
-var colorPageTitle *cview.TextView = cview.NewTextView()
-var jsonColors *cview.Flex = cview.NewFlex()
-var colorPages *cview.Pages = cview.NewPages()
-var colorPageIndex int
+	case p.eventMatches("grayscale", event):
+		if !p.typing() {
+			p.setGrayscale(!p.grayscale)
+		}
 
-var colorInfo []jsonColorInfo
+	case p.eventMatches("random", event):
+		if !p.typing() {
+			p.randomizeColor()
+		}
 
-var helpFlex *cview.Flex = cview.NewFlex()
-var helpModal *cview.Modal = cview.NewModal()
-var helpFocus cview.Primitive = hTable
+	case p.eventMatches("color-mode", event):
+		if !p.typing() {
+			p.toggleColorMode()
+		}
 
-var searchFlex *cview.Flex = cview.NewFlex()
-var searchStatus *cview.TextView = cview.NewTextView()
-var searchInput *cview.InputField = cview.NewInputField()
-var searchNames []string
-var searchIndexes [][]int
-var searchIndex int
+	case p.eventMatches("undo", event):
+		if !p.typing() {
+			p.undoSelection()
+		}
 
-var hFocus cview.Primitive = hTable
-var hue int
+	case p.eventMatches("redo", event):
+		if !p.typing() {
+			p.redoSelection()
+		}
 
-var returnColor ColorValues
+	case p.eventMatches("reload", event):
+		if !p.typing() {
+			p.reloadColors()
+		}
 
-// Input Handlers ---------------------------------------------------------
+	case p.eventMatches("ramp", event):
+		if !p.typing() {
+			p.toggleRamp()
+		}
 
-func inputCaptureHandler(event *tcell.EventKey) *tcell.EventKey {
-	switch {
-	case event.Rune() == 'q':
-		if !searchFlex.HasFocus() {
-			app.Stop()
+	case p.eventMatches("large-preview", event):
+		if !p.typing() {
+			p.setLargePreview(!p.largePreview)
 		}
 
-	case event.Rune() == '`':
-		showHelp()
+	case p.eventMatches("palette-256", event):
+		if !p.typing() {
+			p.toggle256Palette()
+		}
 
-	case event.Key() == tcell.KeyCtrlF || event.Rune() == '?':
-		showSearch()
-		return nil
-	}
+	case p.eventMatches("palette-16", event):
+		if !p.typing() {
+			p.toggle16Palette()
+		}
+
+	case p.eventMatches("sliders", event):
+		if !p.typing() {
+			p.toggleSliders()
+		}
+
+	case p.eventMatches("gradient", event):
+		if !p.typing() {
+			p.toggleGradient()
+		}
+
+	case p.eventMatches("cmyk", event):
+		if !p.typing() {
+			p.toggleCMYK()
+		}
+
+	case p.eventMatches("named-list", event):
+		if !p.typing() {
+			p.toggleNamedList()
+		}
+
+	case p.eventMatches("sv-half", event):
+		if p.svTable.HasFocus() {
+			p.toggleSVHalf()
+		}
+
+	case p.eventMatches("add-favorite", event):
+		if !p.typing() {
+			p.addFavorite()
+		}
 
-	if svTable.HasFocus() {
-		event = svCaptureHandler(event)
-	} else if hTable.HasFocus() {
-		event = hCaptureHandler(event)
-	} else if colorPages.HasFocus() {
-		event = colorPageCaptureHandler(event)
+	case p.eventMatches("save", event):
+		if !p.typing() {
+			p.showSave()
+		}
+
+	case p.eventMatches("emphasis", event):
+		if !p.typing() {
+			p.cycleEmphasis()
+		}
+
+	case p.eventMatches("favorites", event):
+		if !p.typing() {
+			p.toggleFavorites()
+		}
+
+	case p.eventMatches("hex-input", event):
+		if !p.typing() && !p.grayscale {
+			p.pages.SwitchToPage("Hue page")
+			p.app.SetFocus(p.hexInput)
+			return nil
+		}
+
+	case p.eventMatches("hue-input", event):
+		if !p.typing() && !p.grayscale {
+			p.pages.SwitchToPage("Hue page")
+			p.app.SetFocus(p.hueInput)
+			return nil
+		}
 	}
 
-	return event
-}
+	if p.svTable.HasFocus() {
+		event = p.svCaptureHandler(event)
+	} else if p.hTable.HasFocus() {
+		event = p.hCaptureHandler(event)
+	} else if p.colorPages.HasFocus() {
+		event = p.colorPageCaptureHandler(event)
+	}
 
-func svCaptureHandler(event *tcell.EventKey) *tcell.EventKey {
 	return event
 }
 
-func hCaptureHandler(event *tcell.EventKey) *tcell.EventKey {
+func (p *Picker) svCaptureHandler(event *tcell.EventKey) *tcell.EventKey {
 	switch {
-	case event.Rune() == ' ':
-		hFocus = colorPages
-		app.SetFocus(colorPages)
-
-		row, col := colorInfo[colorPageIndex].table.GetSelection()
-		text := colorInfo[colorPageIndex].table.GetCell(row, col).Text
-		raw := strings.Split(string(text[:]), "#")
-		hsv := color.HextoHSV(color.Hex(raw[1]))
-
-		darkHSV := hsv
-		lightHSV := hsv
-		if hsv.V%2 == 0 {
-			darkHSV.V -= 1
-		} else {
-			lightHSV.V += 1
-		}
-		setColorValues(darkHSV, darkHBlock, darkHText, lightHSV, lightHBlock, lightHText)
+	// Change the alpha of the selected color
+	case p.eventMatches("alpha-down", event):
+		p.alpha = math.Max(0, math.Round(p.alpha*10-1)/10)
+		p.svTableSelectionChangedFunc(p.svTable.GetSelection())
+
+	case p.eventMatches("alpha-up", event):
+		p.alpha = math.Min(1, math.Round(p.alpha*10+1)/10)
+		p.svTableSelectionChangedFunc(p.svTable.GetSelection())
+
+	// Change the value and saturation by a step
+	case p.eventMatches("value-up", event):
+		p.nudgeSV(0, p.svStep)
+		return nil
+
+	case p.eventMatches("value-down", event):
+		p.nudgeSV(0, -p.svStep)
+		return nil
+
+	case p.eventMatches("saturation-up", event):
+		p.nudgeSV(p.svStep, 0)
+		return nil
 
-		colorInfo[colorPageIndex].table.SetSelectedStyle(tcell.ColorDefault, tcell.ColorGray, tcell.AttrNone)
+	case p.eventMatches("saturation-down", event):
+		p.nudgeSV(-p.svStep, 0)
+		return nil
 	}
 
 	return event
 }
 
-func colorPageCaptureHandler(event *tcell.EventKey) *tcell.EventKey {
+func (p *Picker) hCaptureHandler(event *tcell.EventKey) *tcell.EventKey {
+	_, col := p.hTable.GetSelection()
+	last := p.hTable.GetColumnCount() - 1
+
 	switch {
-	// Change pages of color tables
-	case event.Rune() == 'C':
-		if colorPageIndex < len(colorInfo)-1 {
-			colorPageIndex++
+	// The table stops at its ends, so wrap the selection around the hues
+	// here instead
+	case p.hueWrap && col == 0 && (event.Rune() == 'h' || event.Key() == tcell.KeyLeft):
+		p.hTable.Select(0, last)
+		return nil
+
+	case p.hueWrap && col == last && (event.Rune() == 'l' || event.Key() == tcell.KeyRight):
+		p.hTable.Select(0, 0)
+		return nil
+
+	case p.eventMatches("switch-presets", event) && !p.noPresets:
+		p.hFocus = p.colorPages
+		p.app.SetFocus(p.colorPages)
 
-			colorInfo[colorPageIndex].table.SetSelectedStyle(tcell.ColorDefault, tcell.ColorGray, tcell.AttrNone)
+		p.colorPageSelectionChangedFunc(p.colorInfo[p.colorPageIndex].table.GetSelection())
+
+		p.colorInfo[p.colorPageIndex].table.SetSelectedStyle(tcell.ColorDefault, tcell.ColorGray, tcell.AttrNone)
+	}
 
-			name := colorInfo[colorPageIndex].name
-			colorPageTitle.SetText(name)
+	return event
+}
 
-			pageId := fmt.Sprintf("page-%d", colorPageIndex)
-			colorPages.SwitchToPage(pageId)
+func (p *Picker) colorPageCaptureHandler(event *tcell.EventKey) *tcell.EventKey {
+	// Any key other than a digit uses up the typed count
+	count := p.colorPageCount
+	p.colorPageCount = 0
 
-			row, col := colorInfo[colorPageIndex].table.GetSelection()
-			text := string(colorInfo[colorPageIndex].table.GetCell(row, col).Text[:])
-			raw := strings.Split(text, "#")
-			hsv := color.HextoHSV(color.Hex(raw[1]))
+	step := 1
+	if count > 0 {
+		step = count
+	}
 
-			darkHSV := hsv
-			lightHSV := hsv
-			if hsv.V%2 == 0 {
-				darkHSV.V -= 1
-			} else {
-				lightHSV.V += 1
+	switch {
+	// Type a count before the next or previous page keys
+	case event.Key() == tcell.KeyRune && event.Rune() >= '0' && event.Rune() <= '9':
+		// A count can't start with 0
+		if count > 0 || event.Rune() != '0' {
+			p.colorPageCount = count*10 + int(event.Rune()-'0')
+			if p.colorPageCount > MAX_PAGE_COUNT {
+				p.colorPageCount = MAX_PAGE_COUNT
 			}
-			setColorValues(darkHSV, darkHBlock, darkHText, lightHSV, lightHBlock, lightHText)
 		}
+		return nil
 
-	case event.Rune() == 'c':
-		if colorPageIndex > 0 {
-			colorPageIndex--
+	// Change pages of color tables
+	case p.eventMatches("next-page", event):
+		p.switchColorPage(p.colorPageIndex + step)
 
-			colorInfo[colorPageIndex].table.SetSelectedStyle(tcell.ColorDefault, tcell.ColorGray, tcell.AttrNone)
+	case p.eventMatches("prev-page", event):
+		p.switchColorPage(p.colorPageIndex - step)
 
-			name := colorInfo[colorPageIndex].name
-			colorPageTitle.SetText(name)
+	// Go to the first and last pages of color tables
+	case p.eventMatches("first-page", event):
+		p.switchColorPage(0)
 
-			pageId := fmt.Sprintf("page-%d", colorPageIndex)
-			colorPages.SwitchToPage(pageId)
+	case p.eventMatches("last-page", event):
+		p.switchColorPage(len(p.colorInfo) - 1)
 
-			row, col := colorInfo[colorPageIndex].table.GetSelection()
-			text := string(colorInfo[colorPageIndex].table.GetCell(row, col).Text[:])
-			raw := strings.Split(text, "#")
-			hsv := color.HextoHSV(color.Hex(raw[1]))
+	// Sort the colors of the current page
+	case p.eventMatches("sort", event):
+		p.cycleSort()
 
-			darkHSV := hsv
-			lightHSV := hsv
-			if hsv.V%2 == 0 {
-				darkHSV.V -= 1
-			} else {
-				lightHSV.V += 1
-			}
-			setColorValues(darkHSV, darkHBlock, darkHText, lightHSV, lightHBlock, lightHText)
-		}
+	// Filter the preset colors by name
+	case p.eventMatches("filter", event):
+		p.app.SetFocus(p.filterInput)
+		return nil
 
 		// Switch to hTable
-	case event.Rune() == ' ':
-		hFocus = hTable
-		app.SetFocus(hTable)
+	case p.eventMatches("switch-presets", event):
+		p.hFocus = p.hTable
+		p.app.SetFocus(p.hTable)
+
+		_, col := p.hTable.GetSelection()
+		darkHSV := color.HSV{H: col * p.hueStep, S: 100, V: 100}
+		lightHSV := color.HSV{H: col*p.hueStep + p.hueStep/2, S: 100, V: 100}
+		p.setColorValues(darkHSV, p.darkHBlock, p.darkHText, lightHSV, p.lightHBlock, p.lightHText)
 
-		_, col := hTable.GetSelection()
-		darkHSV := color.HSV{H: col * 2, S: 100, V: 100}
-		lightHSV := color.HSV{H: col*2 + 1, S: 100, V: 100}
-		setColorValues(darkHSV, darkHBlock, darkHText, lightHSV, lightHBlock, lightHText)
+		p.colorInfo[p.colorPageIndex].table.SetSelectedStyle(tcell.ColorDefault, tcell.ColorDefault, tcell.AttrBold)
 
-		colorInfo[colorPageIndex].table.SetSelectedStyle(tcell.ColorDefault, tcell.ColorDefault, tcell.AttrBold)
+	}
+
+	p.searchInputCaptureHandler(event)
+	return p.colorPageMovementHandler(event)
+}
 
+// Switch to the color table of the given page, staying on the first or last
+// page if the index is past them
+func (p *Picker) switchColorPage(index int) {
+	if index > len(p.colorInfo)-1 {
+		index = len(p.colorInfo) - 1
+	}
+	if index < 0 {
+		index = 0
 	}
+	if index == p.colorPageIndex {
+		return
+	}
+
+	p.colorPageIndex = index
+
+	p.colorInfo[p.colorPageIndex].table.SetSelectedStyle(tcell.ColorDefault, tcell.ColorGray, tcell.AttrNone)
 
-	searchInputCaptureHandler(event)
-	return colorPageMovementHandler(event)
+	p.setColorPageTitle()
+
+	p.showColorPage(p.colorPageIndex)
+
+	p.colorPageSelectionChangedFunc(p.colorInfo[p.colorPageIndex].table.GetSelection())
 }
 
 // Handle any movement events by preventing the user from selecting
 // a blank filler cell
-func colorPageMovementHandler(event *tcell.EventKey) *tcell.EventKey {
+func (p *Picker) colorPageMovementHandler(event *tcell.EventKey) *tcell.EventKey {
 	switch {
 	case event.Rune() == 'l' || event.Key() == tcell.KeyRight:
-		row, col := colorInfo[colorPageIndex].table.GetSelection()
-		if col < colorInfo[colorPageIndex].table.GetColumnCount()-1 {
-			cell := colorInfo[colorPageIndex].table.GetCell(row, col+1)
+		row, col := p.colorInfo[p.colorPageIndex].table.GetSelection()
+		if col < p.colorInfo[p.colorPageIndex].table.GetColumnCount()-1 {
+			cell := p.colorInfo[p.colorPageIndex].table.GetCell(row, col+1)
 			if len(cell.Text) == 0 {
 				return nil
 			}
 		}
 
 	case event.Rune() == 'j' || event.Key() == tcell.KeyDown:
-		row, col := colorInfo[colorPageIndex].table.GetSelection()
-		if row < colorInfo[colorPageIndex].table.GetRowCount()-1 {
-			cell := colorInfo[colorPageIndex].table.GetCell(row+1, col)
+		row, col := p.colorInfo[p.colorPageIndex].table.GetSelection()
+		if row < p.colorInfo[p.colorPageIndex].table.GetRowCount()-1 {
+			cell := p.colorInfo[p.colorPageIndex].table.GetCell(row+1, col)
 			if len(cell.Text) == 0 {
 				return nil
 			}
 		}
 
 	case event.Rune() == 'G':
-		row := colorInfo[colorPageIndex].table.GetRowCount() - 1
-		col := colorInfo[colorPageIndex].table.GetColumnCount() - 1
-		for true {
-			cell := colorInfo[colorPageIndex].table.GetCell(row, col)
+		row := p.colorInfo[p.colorPageIndex].table.GetRowCount() - 1
+		col := p.colorInfo[p.colorPageIndex].table.GetColumnCount() - 1
+		for row > 0 {
+			cell := p.colorInfo[p.colorPageIndex].table.GetCell(row, col)
 			if len(cell.Text) != 0 {
 				break
 			} else {
 				row--
 			}
 		}
-		colorInfo[colorPageIndex].table.Select(row, col)
+		p.colorInfo[p.colorPageIndex].table.Select(row, col)
 		return nil
 	}
 
 	return event
 }
 
-func searchInputCaptureHandler(event *tcell.EventKey) *tcell.EventKey {
-	if len(searchIndexes) > 1 {
-		switch event.Rune() {
+func (p *Picker) searchInputCaptureHandler(event *tcell.EventKey) *tcell.EventKey {
+	if len(p.searchIndexes) > 1 {
+		switch {
 		// Go back a selection
-		case 'n':
-			if searchIndex == 0 {
-				searchIndex = len(searchIndexes)
+		case p.eventMatches("prev-match", event):
+			if p.searchIndex == 0 {
+				p.searchIndex = len(p.searchIndexes)
 			}
-			searchIndex--
+			p.searchIndex--
 
-			colorPages.SwitchToPage(fmt.Sprintf("page-%v", searchIndexes[searchIndex][0]))
-			colorPageIndex = searchIndexes[searchIndex][0]
-			colorInfo[colorPageIndex].table.Select(searchIndexes[searchIndex][2], searchIndexes[searchIndex][1])
-			colorInfo[colorPageIndex].table.SetSelectedStyle(tcell.ColorDefault, tcell.ColorGray, tcell.AttrNone)
+			p.showColorPage(p.searchIndexes[p.searchIndex][0])
+			p.colorPageIndex = p.searchIndexes[p.searchIndex][0]
+			p.colorInfo[p.colorPageIndex].table.Select(p.searchIndexes[p.searchIndex][2], p.searchIndexes[p.searchIndex][1])
+			p.colorInfo[p.colorPageIndex].table.SetSelectedStyle(tcell.ColorDefault, tcell.ColorGray, tcell.AttrNone)
 
 		// Go forward a selection
-		case 'N':
-			if searchIndex == len(searchIndexes)-1 {
-				searchIndex = -1
+		case p.eventMatches("next-match", event):
+			if p.searchIndex == len(p.searchIndexes)-1 {
+				p.searchIndex = -1
 			}
-			searchIndex++
+			p.searchIndex++
 
-			colorPages.SwitchToPage(fmt.Sprintf("page-%v", searchIndexes[searchIndex][0]))
-			colorPageIndex = searchIndexes[searchIndex][0]
-			colorInfo[colorPageIndex].table.Select(searchIndexes[searchIndex][2], searchIndexes[searchIndex][1])
-			colorInfo[colorPageIndex].table.SetSelectedStyle(tcell.ColorDefault, tcell.ColorGray, tcell.AttrNone)
+			p.showColorPage(p.searchIndexes[p.searchIndex][0])
+			p.colorPageIndex = p.searchIndexes[p.searchIndex][0]
+			p.colorInfo[p.colorPageIndex].table.Select(p.searchIndexes[p.searchIndex][2], p.searchIndexes[p.searchIndex][1])
+			p.colorInfo[p.colorPageIndex].table.SetSelectedStyle(tcell.ColorDefault, tcell.ColorGray, tcell.AttrNone)
 		}
 	}
 
@@ -404,360 +858,524 @@ func searchInputCaptureHandler(event *tcell.EventKey) *tcell.EventKey {
 
 // Screen setup -----------------------------------------------------------
 
-func hScreenSetup() {
+func (p *Picker) hScreenSetup() {
 	// Dark color value setup
 	darkText := cview.NewTextView()
 	darkText.SetScrollBarVisibility(cview.ScrollBarNever)
 
-	if !smallWidth && !smallHeight {
+	if !p.smallWidth && !p.smallHeight {
 		darkText.SetText("Dark Tint Color")
+		if p.singleTint {
+			darkText.SetText("Color")
+		}
 
-		darkHBlock.SetText(colorBlockWide)
+		p.darkHBlock.SetText(colorBlockWide)
 	} else {
 		darkText.SetText("Color")
 
-		darkHBlock.SetText(colorBlockSmall)
+		p.darkHBlock.SetText(colorBlockSmall)
 	}
 
-	darkHBlock.SetScrollBarVisibility(cview.ScrollBarNever)
+	p.darkHBlock.SetScrollBarVisibility(cview.ScrollBarNever)
 
-	darkHText.SetScrollBarVisibility(cview.ScrollBarNever)
+	p.darkHText.SetScrollBarVisibility(cview.ScrollBarNever)
+	p.darkHText.SetDynamicColors(true)
 
 	darkColorFlex := cview.NewFlex()
 	darkColorFlex.SetDirection(cview.FlexRow)
 	darkColorFlex.AddItem(darkText, 0, 1, false)
-	darkColorFlex.AddItem(darkHBlock, 0, 2, false)
-	darkColorFlex.AddItem(darkHText, 0, 9, false)
+	darkColorFlex.AddItem(p.darkHBlock, 0, 2, false)
+	darkColorFlex.AddItem(p.darkHText, 0, 9, false)
+	p.previewBlocks = append(p.previewBlocks, previewBlock{darkColorFlex, p.darkHBlock})
 
 	// Light color value setup
 	lightText := cview.NewTextView()
 	lightColorFlex := cview.NewFlex()
-	if !smallHeight && !smallWidth {
+	if p.showLightTint() {
 		lightText.SetScrollBarVisibility(cview.ScrollBarNever)
 		lightText.SetText("  Light Tint Color")
 
-		lightHBlock.SetText(colorBlockWide)
+		p.lightHBlock.SetText(colorBlockWide)
 
-		lightHBlock.SetScrollBarVisibility(cview.ScrollBarNever)
+		p.lightHBlock.SetScrollBarVisibility(cview.ScrollBarNever)
 
-		lightHText.SetScrollBarVisibility(cview.ScrollBarNever)
+		p.lightHText.SetScrollBarVisibility(cview.ScrollBarNever)
+		p.lightHText.SetDynamicColors(true)
 
 		lightColorFlex.SetDirection(cview.FlexRow)
 		lightColorFlex.AddItem(lightText, 0, 1, false)
-		lightColorFlex.AddItem(lightHBlock, 0, 2, false)
-		lightColorFlex.AddItem(lightHText, 0, 9, false)
+		lightColorFlex.AddItem(p.lightHBlock, 0, 2, false)
+		lightColorFlex.AddItem(p.lightHText, 0, 9, false)
+		p.previewBlocks = append(p.previewBlocks, previewBlock{lightColorFlex, p.lightHBlock})
 	}
 
 	colorFlex := cview.NewFlex()
 	colorFlex.SetDirection(cview.FlexRow)
 	colorFlex.AddItem(darkColorFlex, 0, 1, false)
-	if !smallHeight && !smallWidth {
+	if p.showLightTint() {
 		colorFlex.AddItem(lightColorFlex, 0, 1, false)
 	}
 
 	// Everything except hTable setup
 	lowerFlex := cview.NewFlex()
 	lowerFlex.SetDirection(cview.FlexColumn)
-	lowerFlex.AddItem(colorFlex, 0, 3, false)
-	lowerFlex.AddItem(jsonColors, 0, 9, false)
+	if p.noPresets {
+		lowerFlex.AddItem(colorFlex, 0, 1, false)
+	} else if p.compact {
+		// Only one of the panels is shown on small screens
+		lowerFlex.AddItem(colorFlex, 0, 1, false)
+		lowerFlex.AddItem(p.jsonColors, 0, 0, false)
+	} else {
+		lowerFlex.AddItem(colorFlex, 0, 3, false)
+		lowerFlex.AddItem(p.jsonColors, 0, 9, false)
+	}
+	p.hLowerFlex = lowerFlex
+	p.hColorFlex = colorFlex
+
+	p.hHelpText.SetTextAlign(cview.AlignRight)
+	p.setSimulationText()
 
-	help := cview.NewTextView()
-	help.SetTextAlign(cview.AlignRight)
-	help.SetText("Press ` to see help")
+	inputFlex := cview.NewFlex()
+	inputFlex.SetDirection(cview.FlexColumn)
+	inputFlex.AddItem(p.hexInput, 0, 1, false)
+	inputFlex.AddItem(p.hueInput, 0, 1, false)
+	inputFlex.AddItem(p.gradientText, 0, 1, false)
+	inputFlex.AddItem(p.hHelpText, 0, 1, false)
 
-	hFlex.SetDirection(cview.FlexRow)
-	hFlex.AddItem(hTable, 0, 1, true)
-	hFlex.AddItem(help, 0, 1, false)
-	hFlex.AddItem(lowerFlex, 0, 20, false)
+	p.hFlex.SetDirection(cview.FlexRow)
+	p.hFlex.AddItem(p.hTable, 0, 1, true)
+	p.hFlex.AddItem(inputFlex, 0, 1, false)
+	p.hFlex.AddItem(p.historyTable, 0, 1, false)
+	p.hFlex.AddItem(lowerFlex, 0, 20, false)
 
 	darkHSV := color.HSV{H: 0, S: 100, V: 100}
 	lightHSV := color.HSV{H: 0, S: 100, V: 100}
-	setColorValues(darkHSV, darkHBlock, darkHText, lightHSV, lightHBlock, lightHText)
+	p.setColorValues(darkHSV, p.darkHBlock, p.darkHText, lightHSV, p.lightHBlock, p.lightHText)
 }
 
-func svScreenSetup() {
+func (p *Picker) svScreenSetup() {
 	// Fill the text with the default values
-	darkSVBlock.SetScrollBarVisibility(cview.ScrollBarNever)
-	lightSVBlock.SetScrollBarVisibility(cview.ScrollBarNever)
+	p.darkSVBlock.SetScrollBarVisibility(cview.ScrollBarNever)
+	p.lightSVBlock.SetScrollBarVisibility(cview.ScrollBarNever)
 
-	if !smallWidth && !smallHeight {
-		darkSVBlock.SetText(colorBlockWide)
+	if !p.smallWidth && !p.smallHeight {
+		p.darkSVBlock.SetText(colorBlockWide)
 
-		lightSVBlock.SetText(colorBlockWide)
+		p.lightSVBlock.SetText(colorBlockWide)
 	} else {
-		darkSVBlock.SetText(colorBlockSmall)
+		p.darkSVBlock.SetText(colorBlockSmall)
 	}
 
 	darkHSV := color.HSV{H: 0, S: 100, V: 99}
 	lightHSV := color.HSV{H: 0, S: 100, V: 100}
-	setColorValues(darkHSV, darkSVBlock, darkSVText, lightHSV, lightSVBlock, lightSVText)
+	p.setColorValues(darkHSV, p.darkSVBlock, p.darkSVText, lightHSV, p.lightSVBlock, p.lightSVText)
+	p.setSVReadout(lightHSV)
+	p.setNearestText(lightHSV)
+	p.setBestText(color.HSVtoRGB(darkHSV))
 
 	// Setup the screen
 	darkTitle := cview.NewTextView()
-	if !smallWidth && !smallHeight {
+	if !p.smallWidth && !p.smallHeight {
 		darkTitle.SetText("  Dark Tint Color")
+		if p.singleTint {
+			darkTitle.SetText("  Color")
+		}
 	} else {
 		darkTitle.SetText("  Color")
 	}
 
-	darkSVText.SetScrollBarVisibility(cview.ScrollBarNever)
+	p.darkSVText.SetScrollBarVisibility(cview.ScrollBarNever)
+	p.darkSVText.SetDynamicColors(true)
+
+	p.svReadout.SetScrollBarVisibility(cview.ScrollBarNever)
+
+	p.nearestText.SetScrollBarVisibility(cview.ScrollBarNever)
+
+	p.contrastText.SetScrollBarVisibility(cview.ScrollBarNever)
+	p.contrastText.SetDynamicColors(true)
+
+	p.bestText.SetScrollBarVisibility(cview.ScrollBarNever)
+	p.bestText.SetDynamicColors(true)
+
+	p.sampleText.SetScrollBarVisibility(cview.ScrollBarNever)
+	p.sampleText.SetDynamicColors(true)
 
 	darkSVFlex := cview.NewFlex()
 	darkSVFlex.SetDirection(cview.FlexRow)
 	darkSVFlex.AddItem(darkTitle, 0, 1, false)
-	darkSVFlex.AddItem(darkSVBlock, 0, 2, false)
-	darkSVFlex.AddItem(darkSVText, 0, 9, false)
+	darkSVFlex.AddItem(p.darkSVBlock, 0, 2, false)
+	darkSVFlex.AddItem(p.svReadout, 2, 0, false)
+	darkSVFlex.AddItem(p.darkSVText, 0, 9, false)
+	p.previewBlocks = append(p.previewBlocks, previewBlock{darkSVFlex, p.darkSVBlock})
+	darkSVFlex.AddItem(p.nearestText, 1, 0, false)
+	darkSVFlex.AddItem(p.gradientText, 1, 0, false)
+	darkSVFlex.AddItem(p.bestText, 1, 0, false)
+	darkSVFlex.AddItem(p.contrastText, 0, 1, false)
+	darkSVFlex.AddItem(p.sampleText, 2, 0, false)
 
 	lightTitle := cview.NewTextView()
 	lightSVFlex := cview.NewFlex()
-	if !smallHeight && !smallWidth {
+	if p.showLightTint() {
 		lightTitle.SetText("  Light Tint Color")
 
-		lightSVText.SetScrollBarVisibility(cview.ScrollBarNever)
+		p.lightSVText.SetScrollBarVisibility(cview.ScrollBarNever)
+		p.lightSVText.SetDynamicColors(true)
 
 		lightSVFlex.SetDirection(cview.FlexRow)
 		lightSVFlex.AddItem(lightTitle, 0, 1, false)
-		lightSVFlex.AddItem(lightSVBlock, 0, 2, false)
-		lightSVFlex.AddItem(lightSVText, 0, 9, false)
+		lightSVFlex.AddItem(p.lightSVBlock, 0, 2, false)
+		lightSVFlex.AddItem(p.lightSVText, 0, 9, false)
+		p.previewBlocks = append(p.previewBlocks, previewBlock{lightSVFlex, p.lightSVBlock})
 	}
 
 	colorFlex := cview.NewFlex()
 	colorFlex.SetDirection(cview.FlexRow)
 	colorFlex.AddItem(darkSVFlex, 0, 1, false)
-	if !smallHeight && !smallWidth {
+	if p.showLightTint() {
 		colorFlex.AddItem(lightSVFlex, 0, 1, false)
 	}
+	if p.showRamp {
+		colorFlex.AddItem(p.rampTable, RAMP_SIZE+1, 0, false)
+	}
 
-	svFlex.AddItem(svTable, 0, 4, false)
-	svFlex.AddItem(colorFlex, 0, 1, false)
+	p.svFlex.AddItem(p.svTable, 0, 4, false)
+	p.svFlex.AddItem(colorFlex, 0, 1, false)
 }
 
 // Help page setup --------------------------------------------------------
 
-func helpPageSetup() {
-	helpModal.SetText(helpString)
-	helpModal.AddButtons([]string{"Exit help"})
+func (p *Picker) helpPageSetup() {
+	p.helpView.SetText(p.helpText(helpString) + "\n\nSearching:\n" + p.helpText(searchHelpString))
+	p.helpView.SetBorder(true)
+	p.helpView.SetTitle(" Help (j/k to scroll, escape or enter to exit) ")
+	p.helpView.SetScrollable(true)
+	p.helpView.SetScrollBarVisibility(cview.ScrollBarAlways)
+	p.helpView.ScrollToBeginning()
 
-	helpModal.SetDoneFunc(helpModalDoneFunc)
+	p.helpView.SetDoneFunc(p.helpDoneFunc)
 
-	helpFlex.AddItem(helpModal, 0, 1, false)
+	p.helpFlex.AddItem(p.helpView, 0, 1, false)
 }
 
-func helpModalDoneFunc(buttonIndex int, buttonLabel string) {
-	if buttonLabel == "Exit help" {
-		if helpFocus == hTable || helpFocus == colorPages {
-			hFlex.RemoveItem(helpFlex)
-		} else if helpFocus == svTable {
-			svFlex.RemoveItem(helpFlex)
-			svFlex.SetDirection(cview.FlexColumn)
-		}
-		app.SetFocus(helpFocus)
+func (p *Picker) helpDoneFunc(key tcell.Key) {
+	if key != tcell.KeyEscape && key != tcell.KeyEnter {
+		return
+	}
+
+	if p.helpFocus == p.hTable || p.helpFocus == p.colorPages || p.helpFocus == p.historyTable {
+		p.hFlex.RemoveItem(p.helpFlex)
+	} else if p.helpFocus == p.svTable {
+		p.svFlex.RemoveItem(p.helpFlex)
+		p.svFlex.SetDirection(cview.FlexColumn)
 	}
+	p.app.SetFocus(p.helpFocus)
 }
 
 // Search page setup ------------------------------------------------------
 
-func searchInputSetup() {
-	for i := 0; i < len(colorInfo); i++ {
-		for _, c := range colorInfo[i].colors {
-			searchNames = append(searchNames, strings.ToLower(c.NAME))
-		}
-	}
+func (p *Picker) searchInputSetup() {
+	p.setSearchNames()
 
-	searchInput.SetLabel("Enter a color name or value to search for: ")
-	searchInput.SetFieldWidth(60)
+	p.searchInput.SetLabel("Enter a color name or value to search for: ")
+	p.searchInput.SetFieldWidth(60)
 
-	searchInput.SetDoneFunc(searchInputDoneFunc)
-	searchInput.SetAutocompleteFunc(searchInputAutocompleteFunc)
+	p.searchInput.SetDoneFunc(p.searchInputDoneFunc)
+	p.searchInput.SetAutocompleteFunc(p.searchInputAutocompleteFunc)
 
-	searchStatus.SetTextColor(tcell.ColorRed)
+	p.searchStatus.SetTextColor(tcell.ColorRed)
 
 	searchHelp := cview.NewTextView()
-	searchHelp.SetText(searchHelpString)
+	searchHelp.SetText(p.helpText(searchHelpString))
+
+	p.searchFlex.SetDirection(cview.FlexRow)
+	p.searchFlex.AddItem(p.searchInput, 0, 1, false)
+	p.searchFlex.AddItem(p.searchStatus, 0, 1, false)
+	p.searchFlex.AddItem(searchHelp, 0, 4, false)
+}
+
+// Get the names of the preset colors for autocompletion
+func (p *Picker) setSearchNames() {
+	p.searchNames = nil
+	for i := 0; i < len(p.colorInfo); i++ {
+		for _, c := range p.colorInfo[i].colors {
+			p.searchNames = append(p.searchNames, strings.ToLower(c.NAME))
+		}
+	}
+}
+
+// Check whether the name of a preset color contains the text
+func (p *Picker) nameContains(text string) bool {
+	for _, name := range p.searchNames {
+		if strings.Contains(name, text) {
+			return true
+		}
+	}
 
-	searchFlex.SetDirection(cview.FlexRow)
-	searchFlex.AddItem(searchInput, 0, 1, false)
-	searchFlex.AddItem(searchStatus, 0, 1, false)
-	searchFlex.AddItem(searchHelp, 0, 4, false)
+	return false
 }
 
-func searchInputDoneFunc(key tcell.Key) {
+func (p *Picker) searchInputDoneFunc(key tcell.Key) {
 	switch key {
 	// Go back to the main application
 	case tcell.KeyEscape:
-		pages.SwitchToPage("Hue page")
-		colorInfo[colorPageIndex].table.Select(0, 0)
-		app.SetFocus(colorPages)
+		p.pages.SwitchToPage("Hue page")
+		if p.noPresets {
+			p.app.SetFocus(p.hTable)
+			return
+		}
+		p.colorInfo[p.colorPageIndex].table.Select(0, 0)
+		p.app.SetFocus(p.colorPages)
 
 	// Select a value on the color tables
 	case tcell.KeyEnter:
-		text := strings.ToLower(strings.TrimSpace(searchInput.GetText()))
+		text := strings.ToLower(strings.TrimSpace(p.searchInput.GetText()))
 
 		if len(text) > 0 {
-			parseSearchText(text)
+			p.parseSearchText(text)
 		}
 	}
 }
 
-func parseSearchText(text string) {
-	raw := strings.Split(strings.TrimSpace(strings.Join(strings.Split(text, ":")[1:], "")), " ")
+func (p *Picker) parseSearchText(text string) {
+	// Set the reference color for the contrast ratio
+	if strings.HasPrefix(text, "bg:") {
+		text = strings.TrimSpace(strings.TrimPrefix(text, "bg:"))
+		if text == "" {
+			p.clearContrastReference()
+		} else {
+			rgb, err := parseColor(text)
+			if err != nil {
+				p.searchStatus.SetText(err.Error())
+				return
+			}
 
-	var ints []int
-	safe := true
-	for _, v := range raw {
-		num, err := strconv.ParseInt(v, 10, 64)
-		if err != nil {
-			safe = false
-			break
+			p.setContrastReference(rgb)
 		}
 
-		ints = append(ints, int(num))
-	}
+		p.pages.SwitchToPage("Saturation-Value page")
+		p.app.SetFocus(p.svTable)
+		p.svTableSelectionChangedFunc(p.svTable.GetSelection())
+
+		p.searchInput.SetText("")
+		p.searchStatus.SetText("")
 
-	if len(text) > 5 && text[0:5] == "ansi:" {
-		searchStatus.SetText("Please enter the RGB values inside of the ansi escape sequence")
-		return
-	} else if !safe && text[0] != '#' && strings.Contains(text, ":") {
-		searchStatus.SetText("Please enter valid numbers")
 		return
 	}
 
-	var hsv color.HSV
-	var statusMessage string
-	switch {
-	case strings.HasPrefix(strings.ToLower(text), strings.ToLower("#")):
-		matched, err := regexp.MatchString(`[0-9a-fA-F]+`, text)
-		testErr(err)
-
-		if matched && len(text) == 7 {
-			hsv = color.HextoHSV(color.Hex(text))
-		} else {
-			statusMessage = "Please enter a valid hexadecimal value"
+	// Search for a color name if no color value is given. Text such as "cad"
+	// is both a hex value and the start of a name, so it is only read as a
+	// hex value if no preset name contains it.
+	_, _, isHex := parseHexAlpha(text)
+	if isHex && !strings.HasPrefix(text, "#") && p.nameContains(text) {
+		isHex = false
+	}
+	if !isHex && !strings.HasPrefix(text, "#") && !strings.Contains(text, ":") {
+		if p.noPresets {
+			p.searchStatus.SetText("The preset colors are hidden, so colors can't be searched by name")
+			return
 		}
 
-	case strings.HasPrefix(strings.ToLower(text), strings.ToLower("rgb:")):
-		for _, v := range ints {
-			if v > 255 || v < 0 {
-				statusMessage = "Please enter valid RGB values (0 < x < 255)"
-			}
-		}
+		locations := p.getColorLocations(text)
+		p.searchIndexes = locations
 
-		if len(ints) == 3 {
-			rgb := color.RGB{R: ints[0], G: ints[1], B: ints[2]}
-			hsv = color.RGBtoHSV(rgb)
-		} else {
-			statusMessage = "Please enter 3 RGB values"
-		}
+		p.pages.SwitchToPage("Hue page")
+		p.app.SetFocus(p.colorPages)
 
-	case strings.HasPrefix(strings.ToLower(text), strings.ToLower("hsv:")):
-		if ints[0] < 0 || ints[0] > 359 {
-			statusMessage = "Please enter a valid hue value (0 < x < 359)"
+		if len(locations) > 0 {
+			p.showColorPage(locations[0][0])
+			p.colorPageIndex = locations[0][0]
+			p.colorInfo[p.colorPageIndex].table.Select(locations[0][2], locations[0][1])
+			p.colorInfo[p.colorPageIndex].table.SetSelectedStyle(tcell.ColorDefault, tcell.ColorGray, tcell.AttrNone)
 		}
-		for _, v := range ints[1:] {
-			if v > 100 || v < 0 {
-				statusMessage = "Please enter valid Saturation and Value values (0 < x < 100)"
-			}
+
+		p.searchInput.SetText("")
+
+		return
+	}
+
+	rgb, a, err := parseColorAlpha(text)
+	if err != nil {
+		p.searchStatus.SetText(err.Error())
+		return
+	}
+
+	// Only a hex value with an alpha channel changes the alpha, which is
+	// also set back to opaque by an alpha channel of ff
+	if isHex && len(strings.TrimPrefix(strings.TrimSpace(text), "#")) == 8 {
+		p.alpha = a
+	}
+	p.selectSVColor(color.RGBtoHSV(rgb))
+
+	// Let the user cycle through the preset colors closest to a hex value
+	if isHex {
+		p.searchIndexes = p.nearestColorLocations(rgb)
+		p.searchIndex = 0
+
+		if len(p.searchIndexes) > 0 {
+			p.colorPageIndex = p.searchIndexes[0][0]
+			p.showColorPage(p.colorPageIndex)
+			p.setColorPageTitle()
+			p.colorInfo[p.colorPageIndex].table.Select(p.searchIndexes[0][2], p.searchIndexes[0][1])
 		}
+	}
 
-		if len(ints) == 3 {
-			hsv = color.HSV{H: ints[0], S: ints[1], V: ints[2]}
-		} else {
-			statusMessage = "Please enter 3 HSV values"
+	p.searchInput.SetText("")
+	p.searchStatus.SetText("")
+}
+
+// Parse a color value the same way as parseColor, along with the alpha of a
+// hex value with an alpha channel (EX: "#ff8800cc"). Other values have an
+// alpha of 1.
+func parseColorAlpha(text string) (color.RGB, float64, error) {
+	rgb, err := parseColor(text)
+	if err != nil {
+		return rgb, 0, err
+	}
+
+	_, a, ok := parseHexAlpha(text)
+	if !ok {
+		a = 1
+	}
+
+	return rgb, a, nil
+}
+
+// Parse a color value (such as "#ffffff" or "rgb: 255 255 255") using the
+// same format as the search bar
+func parseColor(text string) (color.RGB, error) {
+	text = strings.ToLower(strings.TrimSpace(text))
+
+	// Hex values can be given without the "#" and with an alpha channel
+	if hex, _, ok := parseHexAlpha(text); ok {
+		return color.HextoRGB(color.Hex(hex)), nil
+	}
+	if strings.HasPrefix(text, "#") {
+		return color.RGB{}, errors.New("Please enter a valid hexadecimal value (3, 6, or 8 digits)")
+	}
+
+	i := strings.Index(text, ":")
+	if i == -1 {
+		return color.RGB{}, errors.New("Please enter a color value")
+	}
+
+	colorType := strings.TrimSpace(text[:i])
+	if colorType == "ansi" {
+		return color.RGB{}, errors.New("Please enter the RGB values inside of the ansi escape sequence")
+	}
+
+	var ints []int
+	for _, v := range strings.Fields(text[i+1:]) {
+		num, err := strconv.Atoi(v)
+		if err != nil {
+			return color.RGB{}, errors.New("Please enter valid numbers")
+		}
+
+		ints = append(ints, num)
+	}
+
+	switch colorType {
+	case "rgb":
+		if len(ints) != 3 {
+			return color.RGB{}, errors.New("Please enter 3 RGB values")
+		}
+		for _, v := range ints {
+			if v > 255 || v < 0 {
+				return color.RGB{}, errors.New("Please enter valid RGB values (0 < x < 255)")
+			}
 		}
 
-	case strings.HasPrefix(strings.ToLower(text), strings.ToLower("hsl:")):
+		return color.RGB{R: ints[0], G: ints[1], B: ints[2]}, nil
+
+	case "hsv":
+		if len(ints) != 3 {
+			return color.RGB{}, errors.New("Please enter 3 HSV values")
+		}
 		if ints[0] < 0 || ints[0] > 359 {
-			statusMessage = "Please enter a valid hue value (0 < x < 359)"
+			return color.RGB{}, errors.New("Please enter a valid hue value (0 < x < 359)")
 		}
 		for _, v := range ints[1:] {
 			if v > 100 || v < 0 {
-				statusMessage = "Please enter valid Saturation and Length values (0 < x < 100)"
+				return color.RGB{}, errors.New("Please enter valid Saturation and Value values (0 < x < 100)")
 			}
 		}
 
-		if len(ints) == 3 {
-			hsl := color.HSL{H: ints[0], S: ints[1], L: ints[2]}
-			hsv = color.HSLtoHSV(hsl)
-		} else {
-			statusMessage = "Please enter 3 HSL values"
-		}
+		return color.HSVtoRGB(color.HSV{H: ints[0], S: ints[1], V: ints[2]}), nil
 
-	case strings.HasPrefix(strings.ToLower(text), strings.ToLower("cmyk:")):
+	case "hsl":
+		if len(ints) != 3 {
+			return color.RGB{}, errors.New("Please enter 3 HSL values")
+		}
+		if ints[0] < 0 || ints[0] > 359 {
+			return color.RGB{}, errors.New("Please enter a valid hue value (0 < x < 359)")
+		}
 		for _, v := range ints[1:] {
 			if v > 100 || v < 0 {
-				statusMessage = "Please enter valid CMYK values (0 < x < 100)"
+				return color.RGB{}, errors.New("Please enter valid Saturation and Length values (0 < x < 100)")
 			}
 		}
 
-		if len(ints) == 4 {
-			cmyk := color.CMYK{C: ints[0], M: ints[1], Y: ints[2], K: ints[3]}
-			hsv = color.CMYKtoHSV(cmyk)
-		} else {
-			statusMessage = "Please enter 4 CMYK values"
-		}
+		return color.HSLtoRGB(color.HSL{H: ints[0], S: ints[1], L: ints[2]}), nil
 
-	case strings.HasPrefix(strings.ToLower(text), strings.ToLower("decimal:")):
-		if ints[0] < 0 || ints[0] > 16777215 {
-			statusMessage = "Please enter a valid decimal value (0 < x < 16777215)"
+	case "cmyk":
+		if len(ints) != 4 {
+			return color.RGB{}, errors.New("Please enter 4 CMYK values")
 		}
-
-		if len(ints) == 1 {
-			decimal := ints[0]
-			hsv = color.DecimaltoHSV(color.Decimal(decimal))
-		} else {
-			statusMessage = "Please enter 1 decimal value"
+		for _, v := range ints {
+			if v > 100 || v < 0 {
+				return color.RGB{}, errors.New("Please enter valid CMYK values (0 < x < 100)")
+			}
 		}
 
-	default:
-		locations := getColorLocations(text)
-		searchIndexes = locations
-
-		pages.SwitchToPage("Hue page")
-		app.SetFocus(colorPages)
+		return color.CMYKtoRGB(color.CMYK{C: ints[0], M: ints[1], Y: ints[2], K: ints[3]}), nil
 
-		if len(locations) > 0 {
-			colorPages.SwitchToPage(fmt.Sprintf("page-%v", locations[0][0]))
-			colorPageIndex = locations[0][0]
-			colorInfo[colorPageIndex].table.Select(locations[0][2], locations[0][1])
-			colorInfo[colorPageIndex].table.SetSelectedStyle(tcell.ColorDefault, tcell.ColorGray, tcell.AttrNone)
+	case "kelvin":
+		if len(ints) != 1 {
+			return color.RGB{}, errors.New("Please enter 1 color temperature")
+		}
+		if ints[0] < MIN_KELVIN || ints[0] > MAX_KELVIN {
+			return color.RGB{}, fmt.Errorf("Please enter a valid color temperature (%v < x < %v)", MIN_KELVIN, MAX_KELVIN)
 		}
 
-		searchInput.SetText("")
+		return KelvintoRGB(ints[0]), nil
 
-		return
-	}
+	case "decimal":
+		if len(ints) != 1 {
+			return color.RGB{}, errors.New("Please enter 1 decimal value")
+		}
+		if ints[0] < 0 || ints[0] > 16777215 {
+			return color.RGB{}, errors.New("Please enter a valid decimal value (0 < x < 16777215)")
+		}
 
-	if statusMessage != "" {
-		searchStatus.SetText(statusMessage)
-		return
+		return color.DecimaltoRGB(color.Decimal(ints[0])), nil
 	}
 
-	hue = hsv.H
-
-	drawSVTable()
-	svTable.Select(int(math.Round(50-float64(hsv.V/2))), hsv.S)
-
-	pages.SwitchToPage("Saturation-Value page")
-	app.SetFocus(svTable)
-
-	searchInput.SetText("")
-	searchStatus.SetText("")
-	return
-
+	return color.RGB{}, fmt.Errorf("Please enter a valid color type (%v is not a color type)", colorType)
 }
 
-func searchInputAutocompleteFunc(currentText string) []*cview.ListItem {
+func (p *Picker) searchInputAutocompleteFunc(currentText string) []*cview.ListItem {
 	if len(currentText) == 0 {
 		return nil
 	}
 
-	// Create a list of possible selections
+	// Show how a hex value without the "#" or in shorthand is read, unless
+	// the text is searched as a name
 	var entries []string
-	for _, word := range searchNames {
-		if strings.HasPrefix(strings.ToLower(word), strings.ToLower(currentText)) {
-			entries = append(entries, word)
+	if hex, ok := normalizeHex(currentText); ok && currentText != "#"+hex && (strings.HasPrefix(currentText, "#") || !p.nameContains(strings.ToLower(currentText))) {
+		entries = append(entries, "#"+hex)
+	}
+
+	// Create a list of possible selections, with the closest matches first
+	var matches []fuzzyMatch
+	for i, word := range p.searchNames {
+		if score, ok := fuzzyScore(currentText, word); ok {
+			matches = append(matches, fuzzyMatch{word, score, i})
 		}
 	}
+	sortMatches(matches)
+
+	for _, m := range matches {
+		entries = append(entries, m.name)
+	}
 
 	// If the list is 0 or there is only one option that the user already
 	// typed
@@ -778,126 +1396,166 @@ func searchInputAutocompleteFunc(currentText string) []*cview.ListItem {
 
 // Color pages setup ------------------------------------------------------
 
-func colorPageSetup() {
-	colorInfo = make([]jsonColorInfo, 0)
+func (p *Picker) colorPageSetup(data jsonData) {
+	p.drawColorPages(data)
 
-	path, err := getPath()
-	testErr(err)
+	p.colorPageTitle.SetTextAlign(cview.AlignCenter)
 
-	var data jsonData
-	data = getCustomColors(path)
+	// Setup the color page
+	p.jsonColors.SetDirection(cview.FlexRow)
+	p.jsonColors.AddItem(p.colorPageTitle, 0, 1, false)
+	p.jsonColors.AddItem(p.filterInput, 1, 0, false)
+	p.jsonColors.AddItem(p.colorPages, 0, 10, false)
+}
+
+// Build the preset color tables and pages from imported color data
+func (p *Picker) drawColorPages(data jsonData) {
+	p.clearColorPages()
 
 	// Get the lists of all of the imported colors
 	for i := 0; i < len(data.COLORLIST); i++ {
 		c := jsonColorInfo{}
-		colorInfo = append(colorInfo, c)
-		colorInfo[i].name = strings.Title(data.COLORLIST[i].NAME + " pages")
-		colorInfo[i].length = len(data.COLORLIST[i].COLORS)
+		p.colorInfo = append(p.colorInfo, c)
+		p.colorInfo[i].name = strings.Title(data.COLORLIST[i].NAME + " pages")
+		p.colorInfo[i].all = data.COLORLIST[i].COLORS
+
+		p.colorInfo[i].table = cview.NewTable()
+		p.colorInfo[i].table.SetCellPadding(3, 0)
+		p.colorInfo[i].table.SetScrollBarVisibility(cview.ScrollBarNever)
+		p.colorInfo[i].table.SetSelectable(true, true)
+		p.colorInfo[i].table.SetSelectedStyle(tcell.ColorDefault, tcell.ColorDefault, tcell.AttrBold)
+		p.colorInfo[i].table.SetDoneFunc(p.colorPageDoneFunc)
+		p.colorInfo[i].table.SetSelectedFunc(p.colorPageSelectedFunc)
+		p.colorInfo[i].table.SetSelectionChangedFunc(p.colorPageSelectionChangedFunc)
+		p.colorInfo[i].table.SetMouseCapture(p.tableMouseCapture(p.colorInfo[i].table, p.colorPageSelectedFunc))
+	}
 
-		colorInfo[i].colors = data.COLORLIST[i].COLORS
-		for j := 0; j < 8; j++ {
-			colorInfo[i].colors = append(colorInfo[i].colors, jsonColor{"", "000000"})
-		}
+	// Make pages to hold the tables for all of the colors. Only the table of
+	// the first page is drawn right away.
+	p.colorPageIndex = 0
+	for colorIndex := 0; colorIndex < len(p.colorInfo); colorIndex++ {
+		p.drawColorTable(colorIndex)
 
-		colorInfo[i].table = cview.NewTable()
-		colorInfo[i].table.SetCellPadding(3, 0)
-		colorInfo[i].table.SetScrollBarVisibility(cview.ScrollBarNever)
-		colorInfo[i].table.SetSelectable(true, true)
-		colorInfo[i].table.SetSelectedStyle(tcell.ColorDefault, tcell.ColorDefault, tcell.AttrBold)
-		colorInfo[i].table.SetDoneFunc(colorPageDoneFunc)
-		colorInfo[i].table.SetSelectedFunc(colorPageSelectedFunc)
-		colorInfo[i].table.SetSelectionChangedFunc(colorPageSelectionChangedFunc)
+		pageId := fmt.Sprintf("page-%d", colorIndex)
+		p.colorPages.AddPage(pageId, p.colorInfo[colorIndex].table, true, false)
 	}
+	p.showColorPage(0)
+	p.setColorPageTitle()
+}
 
-	// Make pages to hold the tables for all of the colors
-	for colorIndex := 0; colorIndex < len(colorInfo); colorIndex++ {
-		for x := 0; x < int(math.Ceil(float64(colorInfo[colorIndex].length/9)))+1; x++ {
-			for y := 0; y < 9; y++ {
-				rgb := color.HextoRGB(color.Hex(colorInfo[colorIndex].colors[x*9+y].VALUE))
-				name := strings.ToLower(colorInfo[colorIndex].colors[x*9+y].NAME)
-				val := strings.ToLower(colorInfo[colorIndex].colors[x*9+y].VALUE)
+// Remove the pages of any previously imported colors
+func (p *Picker) clearColorPages() {
+	for i := range p.colorInfo {
+		p.colorPages.RemovePage(fmt.Sprintf("page-%d", i))
+	}
+	p.colorInfo = make([]jsonColorInfo, 0)
+}
 
-				// Draw the color if it can actually be seen
-				if colorInfo[colorIndex].colors[x*9+y].NAME == "" {
-					cell := cview.NewTableCell("")
-					cell.SetTextColor(0)
+// Set the title of the preset colors to the name of the current page
+func (p *Picker) setColorPageTitle() {
+	title := p.colorInfo[p.colorPageIndex].name
+	if p.colorInfo[p.colorPageIndex].sort != 0 {
+		title += fmt.Sprintf(" (sorted by %v)", sortModes[p.colorInfo[p.colorPageIndex].sort])
+	}
 
-					colorInfo[colorIndex].table.SetCell(y, x, cell)
-				} else if rgb.R+rgb.G+rgb.B > 84 {
-					text := fmt.Sprintf(colorPageText, name, val)
-					c := tcell.NewHexColor(int32(color.HextoDecimal(color.Hex(val))))
+	p.colorPageTitle.SetText(title)
+}
 
-					cell := cview.NewTableCell(text)
-					cell.SetTextColor(c)
+// Lay out the colors of a preset color list in its table, using the sort
+// mode of the list. Only the cells of the shown page are drawn right away,
+// and the other pages are drawn once they are shown, so that large color
+// files don't slow down starting. Searching only needs the laid out colors.
+func (p *Picker) drawColorTable(i int) {
+	p.colorInfo[i].colors = filterColors(sortColors(p.colorInfo[i].all, p.colorInfo[i].sort), p.colorFilter)
+	p.colorInfo[i].length = len(p.colorInfo[i].colors)
+
+	// Blank filler cells fill up the last column of the table
+	for j := 0; j < 9; j++ {
+		p.colorInfo[i].colors = append(p.colorInfo[i].colors, jsonColor{VALUE: "000000"})
+	}
 
-					colorInfo[colorIndex].table.SetCell(y, x, cell)
-				} else {
-					text := fmt.Sprintf("██████████  [white]%v  %v  ", name, val)
-					c := tcell.NewHexColor(int32(color.HextoDecimal(color.Hex(val))))
+	p.colorInfo[i].table.Clear()
+	p.colorInfo[i].drawn = false
+	if i == p.colorPageIndex {
+		p.drawColorCells(i)
+	}
+}
 
-					cell := cview.NewTableCell(text)
-					cell.SetTextColor(c)
+// Switch to the page of a color table, drawing the table first if it hasn't
+// been drawn yet
+func (p *Picker) showColorPage(i int) {
+	if !p.colorInfo[i].drawn {
+		p.drawColorCells(i)
+	}
 
-					colorInfo[colorIndex].table.SetCell(y, x, cell)
-				}
+	p.colorPages.SwitchToPage(fmt.Sprintf("page-%d", i))
+}
+
+// Draw the cells of a color table from its laid out colors
+func (p *Picker) drawColorCells(i int) {
+	p.colorInfo[i].drawn = true
+	for x := 0; x < int(math.Ceil(float64(p.colorInfo[i].length/9)))+1; x++ {
+		for y := 0; y < 9; y++ {
+			rgb := p.colorInfo[i].colors[x*9+y].RGB
+			name := strings.ToLower(p.colorInfo[i].colors[x*9+y].NAME)
+			val := strings.ToLower(p.colorInfo[i].colors[x*9+y].VALUE)
+
+			// Draw the color if it can actually be seen
+			if p.colorInfo[i].colors[x*9+y].NAME == "" {
+				cell := cview.NewTableCell("")
+				cell.SetTextColor(0)
+
+				p.colorInfo[i].table.SetCell(y, x, cell)
+			} else if tag := p.readableTag(rgb); tag == "" {
+				text := fmt.Sprintf(colorPageText, name, val)
+				c := tcell.NewHexColor(int32(color.HextoDecimal(color.Hex(val))))
+
+				cell := cview.NewTableCell(text)
+				cell.SetTextColor(c)
+
+				p.colorInfo[i].table.SetCell(y, x, cell)
+			} else {
+				text := fmt.Sprintf("██████████  %v%v  %v  ", tag, name, val)
+				c := tcell.NewHexColor(int32(color.HextoDecimal(color.Hex(val))))
+
+				cell := cview.NewTableCell(text)
+				cell.SetTextColor(c)
+
+				p.colorInfo[i].table.SetCell(y, x, cell)
 			}
 		}
-		pageId := fmt.Sprintf("page-%d", colorIndex)
-		colorPages.AddPage(pageId, colorInfo[colorIndex].table, true, false)
 	}
-	colorPages.SwitchToPage("page-0")
-
-	colorPageTitle.SetTextAlign(cview.AlignCenter)
-	colorPageTitle.SetText(strings.Title(colorInfo[0].name))
-
-	// Setup the color page
-	jsonColors.SetDirection(cview.FlexRow)
-	jsonColors.AddItem(colorPageTitle, 0, 1, false)
-	jsonColors.AddItem(colorPages, 0, 10, false)
 }
 
-func colorPageDoneFunc(key tcell.Key) {
+func (p *Picker) colorPageDoneFunc(key tcell.Key) {
 	switch {
-	case key == tcell.KeyEscape:
-		app.Stop()
-	case key == tcell.KeyTab:
-		pages.SwitchToPage("Saturation-Value page")
-		app.SetFocus(svTable)
+	case p.keyMatches("quit", key, 0):
+		p.app.Stop()
+	case p.keyMatches("switch-table", key, 0):
+		p.switchTable()
 	}
 }
 
-func colorPageSelectedFunc(row int, column int) {
-	// Switch to blank saturation-value page
-	svTable.ScrollToBeginning()
-	svTable.Clear()
-	pages.SwitchToPage("Saturation-Value page")
-	app.SetFocus(svTable)
-
+func (p *Picker) colorPageSelectedFunc(row int, column int) {
 	// Get the color displayed in the table
-	text := colorInfo[colorPageIndex].table.GetCell(row, column).Text
+	text := p.colorInfo[p.colorPageIndex].table.GetCell(row, column).Text
 	raw := strings.Split(string(text[:]), "#")
+	if len(raw) < 2 {
+		return
+	}
 	hsv := color.HextoHSV(color.Hex(raw[1]))
-	cursor := color.HSVtoRGB(color.HSV{H: (hsv.H + 180) % 360, S: 100, V: 100})
-	c := tcell.NewRGBColor(int32(cursor.R), int32(cursor.G), int32(cursor.B))
-	svTable.SetSelectedStyle(c, c, tcell.AttrNone)
-
-	hue = hsv.H
 
-	drawSVTable()
-
-	// Move the user to the selected color
-	x := hsv.S
-	y := 50 - hsv.V/2
-	if hsv.V%2 == 1 {
-		y--
-	}
-	svTable.Select(y, x)
+	p.selectSVColor(hsv)
 }
 
-func colorPageSelectionChangedFunc(row int, column int) {
-	// Get the color from the table
-	text := colorInfo[colorPageIndex].table.GetCell(row, column).Text
+func (p *Picker) colorPageSelectionChangedFunc(row int, column int) {
+	// Get the color from the table. The page can be empty if no colors
+	// match the filter.
+	text := p.colorInfo[p.colorPageIndex].table.GetCell(row, column).Text
 	raw := strings.Split(string(text[:]), "#")
+	if len(raw) < 2 {
+		return
+	}
 	hsv := color.HextoHSV(color.Hex(raw[1]))
 
 	// Fill the color format string with the correct values for the selected
@@ -910,153 +1568,300 @@ func colorPageSelectionChangedFunc(row int, column int) {
 		lightHSV.V += 1
 	}
 
-	setColorValues(darkHSV, darkHBlock, darkHText, lightHSV, lightHBlock, lightHText)
+	p.setColorValues(darkHSV, p.darkHBlock, p.darkHText, lightHSV, p.lightHBlock, p.lightHText)
 }
 
 // hTable setup ----------------------------------------------------------
 
-func hTableSetup() {
+func (p *Picker) hTableSetup() {
 	// Set the hue table with its necessary properties
-	hTable.SetSelectable(true, true)
-	hTable.Select(0, 0)
-	hTable.SetSelectedStyle(tcell.ColorWhite, tcell.ColorWhite, tcell.AttrNone)
-	hTable.SetCellPadding(0, 0)
-
-	// Color the hue table
-	for h := 0; h < 360; h += 2 {
-		bg := color.HSVtoRGB(color.HSV{H: h + 1, S: 100, V: 100})
-		fg := color.HSVtoRGB(color.HSV{H: h, S: 100, V: 100})
-		bc := tcell.NewRGBColor(int32(bg.R), int32(bg.G), int32(bg.B))
-		c := tcell.NewRGBColor(int32(fg.R), int32(fg.G), int32(fg.B))
+	p.hTable.SetSelectable(true, true)
+	p.hTable.Select(0, 0)
+	p.hTable.SetSelectedStyle(tcell.ColorWhite, tcell.ColorWhite, tcell.AttrNone)
+	p.hTable.SetCellPadding(0, 0)
 
-		cell := cview.NewTableCell("▐")
-		cell.SetBackgroundColor(bc)
-		cell.SetTextColor(c)
-		hTable.SetCell(0, h/2, cell)
-	}
+	p.drawHTable()
 
-	hTable.SetDoneFunc(hTableDoneFunc)
-	hTable.SetSelectedFunc(hTableSelectedFunc)
-	hTable.SetSelectionChangedFunc(hTableSelectionChangedFunc)
+	p.hTable.SetDoneFunc(p.hTableDoneFunc)
+	p.hTable.SetSelectedFunc(p.hTableSelectedFunc)
+	p.hTable.SetSelectionChangedFunc(p.hTableSelectionChangedFunc)
+	p.hTable.SetMouseCapture(p.tableMouseCapture(p.hTable, nil))
 }
 
-func hTableDoneFunc(key tcell.Key) {
+func (p *Picker) hTableDoneFunc(key tcell.Key) {
 	switch {
-	case key == tcell.KeyEscape:
-		app.Stop()
-	case key == tcell.KeyTab:
-		pages.SwitchToPage("Saturation-Value page")
-		app.SetFocus(svTable)
+	case p.keyMatches("quit", key, 0):
+		p.app.Stop()
+	case p.keyMatches("switch-table", key, 0):
+		p.switchTable()
 	}
 }
 
-func hTableSelectedFunc(row int, column int) {
-	hue = column * 2
+func (p *Picker) hTableSelectedFunc(row int, column int) {
+	p.hue = column * p.hueStep
 
 	// Switch to saturation-value page with the correct setup
-	svTable.Clear()
-	pages.SwitchToPage("Saturation-Value page")
-	app.SetFocus(svTable)
-	svTable.Select(0, 100)
-	cursor := color.HSVtoRGB(color.HSV{H: (hue + 180) % 360, S: 100, V: 100})
-	c := tcell.NewRGBColor(int32(cursor.R), int32(cursor.G), int32(cursor.B))
-	svTable.SetSelectedStyle(c, c, tcell.AttrNone)
+	p.svTable.Clear()
+	p.pages.SwitchToPage("Saturation-Value page")
+	p.app.SetFocus(p.svTable)
+	p.selectSVHalf(0, 100)
+	p.setSVCursor()
 
-	drawSVTable()
+	p.drawSVTable()
 
-	darkHSV := color.HSV{H: hue, S: 100, V: 99}
-	lightHSV := color.HSV{H: hue, S: 100, V: 100}
+	darkHSV := color.HSV{H: p.hue, S: 100, V: 99}
+	lightHSV := color.HSV{H: p.hue, S: 100, V: 100}
 
-	setColorValues(darkHSV, darkHBlock, darkHText, lightHSV, lightHBlock, lightHText)
+	p.setColorValues(darkHSV, p.darkHBlock, p.darkHText, lightHSV, p.lightHBlock, p.lightHText)
 }
 
-func hTableSelectionChangedFunc(row int, column int) {
-	darkHSV := color.HSV{H: column * 2, S: 100, V: 100}
-	lightHSV := color.HSV{H: column*2 + 1, S: 100, V: 100}
+func (p *Picker) hTableSelectionChangedFunc(row int, column int) {
+	darkHSV := color.HSV{H: column * p.hueStep, S: 100, V: 100}
+	lightHSV := color.HSV{H: column*p.hueStep + p.hueStep/2, S: 100, V: 100}
 
-	setColorValues(darkHSV, darkHBlock, darkHText, lightHSV, lightHBlock, lightHText)
+	p.setColorValues(darkHSV, p.darkHBlock, p.darkHText, lightHSV, p.lightHBlock, p.lightHText)
 }
 
 // svTable setup ---------------------------------------------------------
 
-func svTableSetup() {
-	drawSVTable()
+func (p *Picker) svTableSetup() {
+	// The number of rows can change between runs, so remove the old rows
+	p.svTable.Clear()
+	p.drawSVTable()
 
 	// 16842751 is cyan which makes the cursor stand out on red table
-	svTable.SetSelectedStyle(16842751, 16842751, tcell.AttrNone)
-	svTable.SetSelectable(true, true)
-	svTable.SetCellPadding(0, 0)
-	svTable.Select(0, 100)
-
-	svTable.SetDoneFunc(svTableDoneFunc)
-	svTable.SetSelectedFunc(svTableSelectedFunc)
-	svTable.SetSelectionChangedFunc(svTableSelectionChangedFunc)
+	p.svTable.SetSelectedStyle(16842751, 16842751, tcell.AttrNone)
+	p.svTable.SetSelectable(true, true)
+	p.svTable.SetCellPadding(0, 0)
+	p.selectSVHalf(0, 100)
+
+	p.svTable.SetDoneFunc(p.svTableDoneFunc)
+	p.svTable.SetSelectedFunc(p.svTableSelectedFunc)
+	p.svTable.SetSelectionChangedFunc(p.svTableSelectionChangedFunc)
+	p.svTable.SetMouseCapture(p.tableMouseCapture(p.svTable, nil))
 }
 
-func svTableDoneFunc(key tcell.Key) {
+func (p *Picker) svTableDoneFunc(key tcell.Key) {
 	switch {
-	case key == tcell.KeyEscape:
-		app.Stop()
-	case key == tcell.KeyTab:
-		pages.SwitchToPage("Hue page")
-		app.SetFocus(hFocus)
+	case p.keyMatches("quit", key, 0):
+		p.app.Stop()
+	case p.keyMatches("switch-table", key, 0):
+		p.switchTable()
 	}
 }
 
-func svTableSelectedFunc(row int, column int) {
-	hsv := color.HSV{H: hue, S: column, V: 100 - row*2}
-	rgb := color.HSVtoRGB(hsv)
-	hsl := color.HSVtoHSL(hsv)
-	cmyk := color.HSVtoCMYK(hsv)
-	hex := color.HSVtoHex(hsv)
-	decimal := color.HSVtoDecimal(hsv)
-	ansi := color.HSVtoAnsi(hsv)
+func (p *Picker) svTableSelectedFunc(row int, column int) {
+	hsv := p.svColor(column, p.svValue(row*2+p.svHalf))
+	altHsv := p.svColor(column, p.svValue(row*2+1-p.svHalf))
 
-	altHsv := color.HSV{H: hue, S: column, V: 99 - row*2}
-	name := getColorName(hsv, altHsv)
-	returnColor = ColorValues{rgb, hsv, hsl, cmyk, hex, decimal, ansi, name}
+	// In sandbox mode the color is only noted and the application keeps
+	// running
+	if p.sandbox {
+		p.noteSandboxColor(newColorValues(hsv, p.getColorName(hsv, altHsv), p.alpha))
+		return
+	}
 
-	app.Stop()
+	p.selectColor(hsv, altHsv)
 }
 
-func svTableSelectionChangedFunc(row int, column int) {
+func (p *Picker) svTableSelectionChangedFunc(row int, column int) {
 	// Set the dark saturation-value block to the correct color and the
 	// saturation-value text to contain the right values
-	var darkHSV color.HSV
-	if row*2+1 > 100 {
-		darkHSV = color.HSV{H: hue, S: column, V: 0}
-	} else {
-		darkHSV = color.HSV{H: hue, S: column, V: 100 - (row*2 + 1)}
+	darkHSV := p.svColor(column, p.svValue(row*2+1))
+	lightHSV := p.svColor(column, p.svValue(row*2))
+	selected := p.svColor(column, p.svValue(row*2+p.svHalf))
+	if p.singleTint {
+		darkHSV, lightHSV = selected, selected
 	}
-	lightHSV := color.HSV{H: hue, S: column, V: 100 - (row * 2)}
-	setColorValues(darkHSV, darkSVBlock, darkSVText, lightHSV, lightSVBlock, lightSVText)
+	p.setColorValues(darkHSV, p.darkSVBlock, p.darkSVText, lightHSV, p.lightSVBlock, p.lightSVText)
+	p.setSVCursor()
+	p.setContrastText(color.HSVtoRGB(selected))
+	p.setBestText(color.HSVtoRGB(selected))
+	p.setSampleText(color.HSVtoRGB(selected))
+	p.setNearestText(selected)
+	p.setSVReadout(selected)
+	p.drawRampTable(selected)
+	p.recordSelection(row, column)
 }
 
 // Helper functions ---------------------------------------------------
 
-func drawSVTable() {
+// Set the cursor of the saturation-value table to the complementary color of
+// the hue so it stands out. Only the selected half of the cell is covered by
+// the cursor, and the other half keeps its color.
+func (p *Picker) setSVCursor() {
+	// Red makes the cursor stand out on the grays
+	c := tcell.ColorRed
+	if !p.grayscale {
+		c = p.renderColor(color.HSVtoRGB(color.HSV{H: (p.hue + 180) % 360, S: 100, V: 100}))
+	}
+
+	row, col := p.svTable.GetSelection()
+	if row >= p.svRows {
+		// The black row isn't split into halves
+		p.svTable.SetSelectedStyle(c, c, tcell.AttrNone)
+		return
+	}
+
+	// The top half is the background of the cell and the bottom half is the
+	// foreground
+	if p.svHalf == 0 {
+		p.svTable.SetSelectedStyle(p.displayColor(color.HSVtoRGB(p.svColor(col, p.svValue(row*2+1)))), c, tcell.AttrNone)
+	} else {
+		p.svTable.SetSelectedStyle(c, p.displayColor(color.HSVtoRGB(p.svColor(col, p.svValue(row*2)))), tcell.AttrNone)
+	}
+}
+
+// Switch between the top and bottom half of the selected cell of the
+// saturation-value table
+func (p *Picker) toggleSVHalf() {
+	p.svHalf = 1 - p.svHalf
+	p.svTableSelectionChangedFunc(p.svTable.GetSelection())
+}
+
+// Select a half row (see svHalfRow) and column of the saturation-value table
+func (p *Picker) selectSVHalf(half int, column int) {
+	p.svHalf = half % 2
+	p.svTable.Select(half/2, column)
+}
+
+// Show the exact hue, saturation, value, and hex value of a color in the
+// readout of the saturation-value screen
+func (p *Picker) setSVReadout(hsv color.HSV) {
+	p.svReadout.SetText(fmt.Sprintf("  H:%3d S:%3d V:%3d\n  #%v", hsv.H, hsv.S, hsv.V, color.HSVtoHex(hsv)))
+}
+
+// Move the selection of the saturation-value table by a change in saturation
+// and value, keeping both between 0 and 100
+func (p *Picker) nudgeSV(ds int, dv int) {
+	row, col := p.svTable.GetSelection()
+
+	s := int(math.Max(0, math.Min(100, float64(col+ds))))
+	v := int(math.Max(0, math.Min(100, float64(p.svValue(row*2+p.svHalf)+dv))))
+
+	p.selectSVHalf(p.svHalfRow(v), s)
+}
+
+// Switch between the hue screen and the saturation-value table
+func (p *Picker) switchTable() {
+	if p.svTable.HasFocus() {
+		// The hue screen is disabled in grayscale mode
+		if p.grayscale {
+			return
+		}
+
+		p.pages.SwitchToPage("Hue page")
+		p.app.SetFocus(p.hFocus)
+	} else if p.hTable.HasFocus() || p.colorPages.HasFocus() || p.historyTable.HasFocus() {
+		p.pages.SwitchToPage("Saturation-Value page")
+		p.app.SetFocus(p.svTable)
+	}
+}
+
+func (p *Picker) drawHTable() {
+	p.hTable.Clear()
+
+	// Color the hue table
+	for h := 0; h < 360; h += p.hueStep {
+		bg := color.HSVtoRGB(color.HSV{H: h + p.hueStep/2, S: 100, V: 100})
+		fg := color.HSVtoRGB(color.HSV{H: h, S: 100, V: 100})
+
+		cell := cview.NewTableCell("▐")
+		cell.SetBackgroundColor(p.displayColor(bg))
+		cell.SetTextColor(p.displayColor(fg))
+		p.hTable.SetCell(0, h/p.hueStep, cell)
+	}
+}
+
+func (p *Picker) drawSVTable() {
 	// Draw the table with the correct hue
 	for s := 0; s <= 100; s++ {
-		for v := 0; v < 50; v++ {
-			bg := color.HSVtoRGB(color.HSV{H: hue, S: s, V: 100 - v*2})
-			fg := color.HSVtoRGB(color.HSV{H: hue, S: s, V: 100 - (v*2 + 1)})
-			bc := tcell.NewRGBColor(int32(bg.R), int32(bg.G), int32(bg.B))
-			c := tcell.NewRGBColor(int32(fg.R), int32(fg.G), int32(fg.B))
+		for v := 0; v < p.svRows; v++ {
+			bg := color.HSVtoRGB(p.svColor(s, p.svValue(v*2)))
+			fg := color.HSVtoRGB(p.svColor(s, p.svValue(v*2+1)))
 
 			cell := cview.NewTableCell("▄")
-			cell.SetBackgroundColor(bc)
-			cell.SetTextColor(c)
-			svTable.SetCell(v, s, cell)
+			cell.SetBackgroundColor(p.displayColor(bg))
+			cell.SetTextColor(p.displayColor(fg))
+			p.svTable.SetCell(v, s, cell)
 		}
 	}
 	for i := 0; i <= 100; i++ {
 		cell := cview.NewTableCell(" ")
 		cell.SetBackgroundColor(0)
-		svTable.SetCell(50, i, cell)
+		p.svTable.SetCell(p.svRows, i, cell)
 	}
 }
 
-func setColorValues(darkHSV color.HSV, darkBlock *cview.TextView, darkText *cview.TextView, lightHSV color.HSV, lightBlock *cview.TextView, lightText *cview.TextView) {
+// Select the final color and stop the application once enough colors are
+// selected
+func (p *Picker) selectColor(hsv color.HSV, altHsv color.HSV) {
+	p.selectColorValues(newColorValues(hsv, p.getColorName(hsv, altHsv), p.alpha))
+}
+
+// Select the final color values and stop the application once enough colors
+// are selected
+func (p *Picker) selectColorValues(c ColorValues) {
+	p.returnColor = c
+	p.addHistory(p.returnColor.Hex)
+
+	saveLastColor(string(p.returnColor.Hex))
+
+	if p.pickColor(p.returnColor) {
+		return
+	}
+
+	p.app.Stop()
+}
+
+// Create the color values of a color with the given name
+func newColorValues(hsv color.HSV, name string, alpha float64) ColorValues {
+	rgb := color.HSVtoRGB(hsv)
+	hsl := color.HSVtoHSL(hsv)
+	cmyk := color.HSVtoCMYK(hsv)
+	hex := color.HSVtoHex(hsv)
+	decimal := color.HSVtoDecimal(hsv)
+	ansi := color.HSVtoAnsi(hsv)
+
+	return ColorValues{rgb, hsv, hsl, HSVtoHWB(hsv), cmyk, hex, decimal, ansi, name, alpha, nearestAnsi256(rgb)}
+}
+
+// Create the color values of an RGB color, using the name of the preset color
+// that matches it
+func newRGBColorValues(rgb color.RGB, presets []jsonColor) ColorValues {
+	hsv := color.RGBtoHSV(rgb)
+	hsl := color.RGBtoHSL(rgb)
+	cmyk := color.RGBtoCMYK(rgb)
+	hex := color.RGBtoHex(rgb)
+	decimal := color.RGBtoDecimal(rgb)
+	ansi := color.RGBtoAnsi(rgb)
+
+	return ColorValues{rgb, hsv, hsl, HSVtoHWB(hsv), cmyk, hex, decimal, ansi, colorName(presets, hsv, hsv), 1, nearestAnsi256(rgb)}
+}
+
+// Switch to the saturation-value table and move the user to the given color
+func (p *Picker) selectSVColor(hsv color.HSV) {
+	p.svTable.ScrollToBeginning()
+	p.svTable.Clear()
+	p.pages.SwitchToPage("Saturation-Value page")
+	p.app.SetFocus(p.svTable)
+
+	p.hue = hsv.H
+	p.setSVCursor()
+
+	p.drawSVTable()
+
+	// Move the user to the selected color
+	p.selectSVHalf(p.svHalfRow(hsv.V), hsv.S)
+}
+
+func (p *Picker) setColorValues(darkHSV color.HSV, darkBlock *cview.TextView, darkText *cview.TextView, lightHSV color.HSV, lightBlock *cview.TextView, lightText *cview.TextView) {
+	// Only the light tint is shown in single tint mode, in the place of the
+	// dark tint
+	if p.singleTint {
+		darkHSV = lightHSV
+	}
+
 	if darkHSV.S > 100 {
 		darkHSV.S = 100
 	} else if darkHSV.S < 0 {
@@ -1082,181 +1887,684 @@ func setColorValues(darkHSV color.HSV, darkBlock *cview.TextView, darkText *cvie
 	}
 
 	// Fill in the color blocks with the color info
-	darkRGB := color.HSVtoRGB(darkHSV)
-	darkHSL := color.HSVtoHSL(darkHSV)
-	darkCMYK := color.HSVtoCMYK(darkHSV)
-	darkHex := color.HSVtoHex(darkHSV)
-	darkDecimal := color.HSVtoDecimal(darkHSV)
-	darkAnsi := color.HSVtoAnsi(darkHSV)
-
-	lightRGB := color.HSVtoRGB(lightHSV)
-	lightHSL := color.HSVtoHSL(lightHSV)
-	lightCMYK := color.HSVtoCMYK(lightHSV)
-	lightHex := color.HSVtoHex(lightHSV)
-	lightDecimal := color.HSVtoDecimal(lightHSV)
-	lightAnsi := color.HSVtoAnsi(lightHSV)
-
-	if !smallWidth && !smallHeight {
-		dc := tcell.NewRGBColor(int32(darkRGB.R), int32(darkRGB.G), int32(darkRGB.B))
-		darkBlock.SetTextColor(dc)
-		dText := fmt.Sprintf(colorTextWide, darkRGB.R, darkRGB.G, darkRGB.B, darkHSV.H, darkHSV.S, darkHSV.V, darkHSL.H, darkHSL.S, darkHSL.L, darkCMYK.C, darkCMYK.M, darkCMYK.Y, darkCMYK.K, darkHex, darkDecimal, darkAnsi)
-		darkText.SetText(dText)
-
-		lc := tcell.NewRGBColor(int32(lightRGB.R), int32(lightRGB.G), int32(lightRGB.B))
-		lightBlock.SetTextColor(lc)
-		lText := fmt.Sprintf(colorTextWide, lightRGB.R, lightRGB.G, lightRGB.B, lightHSV.H, lightHSV.S, lightHSV.V, lightHSL.H, lightHSL.S, lightHSL.L, lightCMYK.C, lightCMYK.M, lightCMYK.Y, lightCMYK.K, lightHex, lightDecimal, lightAnsi)
-		lightText.SetText(lText)
+	if !p.smallWidth && !p.smallHeight {
+		darkBlock.SetTextColor(p.displayColor(color.HSVtoRGB(darkHSV)))
+		darkText.SetText(p.colorText(darkHSV, true))
+
+		lightBlock.SetTextColor(p.displayColor(color.HSVtoRGB(lightHSV)))
+		lightText.SetText(p.colorText(lightHSV, true))
 	} else {
-		dc := tcell.NewRGBColor(int32(darkRGB.R), int32(darkRGB.G), int32(darkRGB.B))
-		darkBlock.SetTextColor(dc)
-		dText := fmt.Sprintf(colorTextSmall, darkRGB.R, darkRGB.G, darkRGB.B, darkHSV.H, darkHSV.S, darkHSV.V, darkHSL.H, darkHSL.S, darkHSL.L, darkCMYK.C, darkCMYK.M, darkCMYK.Y, darkCMYK.K, darkHex, darkDecimal, darkAnsi)
-		darkText.SetText(dText)
+		darkBlock.SetTextColor(p.displayColor(color.HSVtoRGB(darkHSV)))
+		darkText.SetText(p.colorText(darkHSV, false))
 	}
 }
 
-func getColorName(hsv color.HSV, altHSV color.HSV) string {
-	// If one of the preset colors is equal to the selected hsv, return the name
-	var h color.HSV
-	for i := 0; i < len(colorInfo); i++ {
-		for _, c := range colorInfo[i].colors {
-			h = color.HextoHSV(color.Hex(c.VALUE))
-			if h == hsv || h == altHSV {
-				return c.NAME
-			}
+// Get the color values of a color, with the emphasized type moved to the top
+// in bold
+func (p *Picker) colorText(hsv color.HSV, wide bool) string {
+	rgb := color.HSVtoRGB(hsv)
+	hsl := color.HSVtoHSL(hsv)
+	hwb := HSVtoHWB(hsv)
+	cmyk := color.HSVtoCMYK(hsv)
+
+	values := [][]interface{}{
+		{rgb.R, rgb.G, rgb.B},
+		{hsv.H, hsv.S, hsv.V},
+		{hsl.H, hsl.S, hsl.L},
+		{hwb.H, hwb.W, hwb.B},
+		{cmyk.C, cmyk.M, cmyk.Y, cmyk.K},
+		{color.HSVtoHex(hsv)},
+		{color.HSVtoDecimal(hsv)},
+		{color.HSVtoAnsi(hsv)},
+		{p.alpha},
+	}
+
+	formats, indent := colorTextSmall, ""
+	if wide {
+		formats, indent = colorTextWide, "  "
+	}
+
+	lines := make([]string, len(formats))
+	for i, format := range formats {
+		lines[i] = indent + fmt.Sprintf(format, values[i]...)
+	}
+
+	if p.emphasis > 0 {
+		i := p.emphasis - 1
+		top := indent + "[::bu]" + fmt.Sprintf(formats[i], values[i]...) + "[::-]"
+		lines = append([]string{top}, append(lines[:i:i], lines[i+1:]...)...)
+	}
+
+	return "\n" + strings.Join(lines, "\n\n") + "\n"
+}
+
+// Check if a hex value (without the "#") is a valid six digit hex value
+func validHex(hex string) bool {
+	matched, err := regexp.MatchString(`^[0-9a-fA-F]{6}$`, hex)
+	return err == nil && matched
+}
+
+// Get the lowercase six digit hex value (without the "#") of a hex value
+// with or without the "#". Three digit shorthand values are expanded (EX:
+// "f80" is "ff8800").
+func normalizeHex(text string) (string, bool) {
+	hex := strings.ToLower(strings.TrimPrefix(strings.TrimSpace(text), "#"))
+
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+
+	if !validHex(hex) {
+		return "", false
+	}
+
+	return hex, true
+}
+
+// Get the six digit hex value and the alpha of a hex value that can have an
+// alpha channel (EX: "#ff8800cc" is "ff8800" with an alpha of 0.8). Hex
+// values without an alpha channel have an alpha of 1.
+func parseHexAlpha(text string) (string, float64, bool) {
+	text = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(text), "#"))
+	if len(text) != 8 {
+		hex, ok := normalizeHex(text)
+		return hex, 1, ok
+	}
+
+	hex, ok := normalizeHex(text[:6])
+	a, err := strconv.ParseUint(text[6:], 16, 8)
+	if !ok || err != nil {
+		return "", 0, false
+	}
+
+	// Three decimals are enough to get the same alpha channel back
+	return hex, math.Round(float64(a)/255*1000) / 1000, true
+}
+
+func (p *Picker) getColorName(hsv color.HSV, altHSV color.HSV) string {
+	return colorName(p.presetColors(), hsv, altHSV)
+}
+
+// Get the name of the preset color that is equal to a color or its other
+// tint, or a name for a custom color
+func colorName(presets []jsonColor, hsv color.HSV, altHSV color.HSV) string {
+	for _, c := range presets {
+		if c.HSV == hsv || c.HSV == altHSV {
+			return c.NAME
 		}
 	}
+
+	if GenerateNames {
+		return generateColorName(presets, hsv)
+	}
 	return "custom color"
 }
 
+// Get all of the preset colors of the picker, ignoring the sort and filter of
+// the pages. Without the preset color pages (EX: with -no-presets), the
+// colors read from the color files are used.
+func (p *Picker) presetColors() []jsonColor {
+	if len(p.colorInfo) == 0 {
+		return p.fileColors
+	}
+
+	var colors []jsonColor
+	for _, v := range p.colorInfo {
+		colors = append(colors, v.all...)
+	}
+	return colors
+}
+
+// Get all of the preset colors of the color files for a palette. The files
+// are read once for each palette, so that colors can be named without a
+// running picker (such as when using Convert).
+func filePresetColors(palette string) []jsonColor {
+	fileColorsMutex.Lock()
+	file, ok := fileColors[palette]
+	if !ok {
+		file = &presetColorFile{}
+		fileColors[palette] = file
+	}
+	fileColorsMutex.Unlock()
+
+	file.once.Do(func() {
+		data, err := getCustomColors(palette)
+		if err != nil {
+			return
+		}
+		file.colors = listColors(data)
+	})
+
+	return file.colors
+}
+
+// Get all of the colors of every color list
+func listColors(data jsonData) []jsonColor {
+	var colors []jsonColor
+	for _, v := range data.COLORLIST {
+		colors = append(colors, v.COLORS...)
+	}
+	return colors
+}
+
+// presetColorFile holds the preset colors read from the color files for a
+// palette
+type presetColorFile struct {
+	once   sync.Once
+	colors []jsonColor
+}
+
+// The preset colors read from the color files by filePresetColors, keyed by
+// palette
+var fileColors = map[string]*presetColorFile{}
+var fileColorsMutex sync.Mutex
+
 // Get the location of a searched color
-func getColorLocations(name string) [][]int {
-	var locations [][]int
-	for i := 0; i < len(colorInfo); i++ {
-		for x := 0; x < int(math.Ceil(float64(colorInfo[i].length/9)))+1; x++ {
+func (p *Picker) getColorLocations(name string) [][]int {
+	var all [][]int
+	var matches []fuzzyMatch
+	for i := 0; i < len(p.colorInfo); i++ {
+		for x := 0; x < int(math.Ceil(float64(p.colorInfo[i].length/9)))+1; x++ {
 			for y := 0; y < 9; y++ {
-				if strings.Contains(strings.ToLower(colorInfo[i].colors[x*9+y].NAME), name) {
+				colorName := p.colorInfo[i].colors[x*9+y].NAME
+				if score, ok := fuzzyScore(name, colorName); ok {
 					var location = []int{i, x, y}
 
-					locations = append(locations, location)
+					matches = append(matches, fuzzyMatch{colorName, score, len(all)})
+					all = append(all, location)
 				}
 			}
 		}
 	}
 
+	// Put the closest matches first
+	sortMatches(matches)
+
+	var locations [][]int
+	for _, m := range matches {
+		locations = append(locations, all[m.index])
+	}
+
 	return locations
 }
 
 func getPath() (string, error) {
-	usr, err := user.Current()
-	testErr(err)
+	// The home directory is found the same way as the other config files
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
 
-	homeDir := usr.HomeDir
-	paths := [...]string{"./colors.json", homeDir + "/.config/cpick/colors.json", homeDir + "/.cpick/colors.json"}
+	configColors, err := configPath("colors.json")
+	if err != nil {
+		return "", err
+	}
+	paths := [...]string{"./colors.json", configColors, homeDir + "/.cpick/colors.json"}
 
 	for i := 0; i < 3; i++ {
 		if _, err := os.Stat(paths[i]); err == nil { // Path exists
 			return paths[i], nil
-		} else if os.IsNotExist(err) { // Path does not exist
-			continue
+		} else if !os.IsNotExist(err) { // An error occurred
+			return "", err
 		}
 	}
-	// Else: an error occurred
-	return "", err
+
+	// No custom colors, so the preset colors are used
+	return "", nil
 }
 
-func getCustomColors(path string) jsonData {
-	var data jsonData
-	if path != "" {
-		raw, err := ioutil.ReadFile(path)
-		if testingMode {
-			err = nil
+// Find and read the color data. The colors of every palette file are added to
+// the custom or preset colors. If a palette name is given, only the colors of
+// that palette are used.
+func getCustomColors(palette string) (jsonData, error) {
+	lists, err := loadPalettes(palette)
+	if err != nil {
+		return jsonData{}, err
+	}
+
+	if palette != "" {
+		if len(lists) == 0 {
+			return jsonData{}, fmt.Errorf("Palette %q does not contain any color lists", palette)
 		}
-		testErr(err)
+		return jsonData{COLORLIST: lists}, nil
+	}
 
-		if testingMode {
-			raw = []byte(presetData)
+	path, err := getPath()
+	if err != nil {
+		return jsonData{}, err
+	}
+
+	data, err := loadCustomColors(path)
+	if err != nil {
+		return data, err
+	}
+	data.COLORLIST = append(data.COLORLIST, lists...)
+
+	return data, nil
+}
+
+// Read the color data from a path, or the preset data if the path is empty
+func loadCustomColors(path string) (jsonData, error) {
+	var data jsonData
+
+	if path == "" {
+		if err := json.Unmarshal([]byte(presetData), &data); err != nil {
+			return data, err
 		}
+		computeColors(&data)
 
-		err = json.Unmarshal([]byte(raw), &data)
-		testErr(err)
-	} else {
-		err := json.Unmarshal([]byte(presetData), &data)
-		testErr(err)
+		return data, nil
 	}
 
-	return data
+	data, err := loadColorFile(path, loadConfigFile().CacheColors)
+	if err != nil {
+		return data, err
+	}
+	if len(data.COLORLIST) == 0 {
+		return data, fmt.Errorf("%v does not contain any color lists", path)
+	}
+
+	return data, nil
 }
 
-func showHelp() {
-	if searchFlex.HasFocus() {
+func (p *Picker) showHelp() {
+	if p.typing() {
 		return
 	}
 
-	if hTable.HasFocus() {
-		helpFocus = hTable
-		hFlex.AddItem(helpFlex, 100, 1, false)
-	} else if colorPages.HasFocus() {
-		helpFocus = colorPages
-		hFlex.AddItem(helpFlex, 100, 1, false)
-	} else if svTable.HasFocus() {
-		helpFocus = svTable
-		svFlex.SetDirection(cview.FlexRow)
-		svFlex.AddItem(helpFlex, 100, 1, false)
+	if p.hTable.HasFocus() {
+		p.helpFocus = p.hTable
+		p.hFlex.AddItem(p.helpFlex, 100, 1, false)
+	} else if p.colorPages.HasFocus() {
+		p.helpFocus = p.colorPages
+		p.hFlex.AddItem(p.helpFlex, 100, 1, false)
+	} else if p.historyTable.HasFocus() {
+		p.helpFocus = p.historyTable
+		p.hFlex.AddItem(p.helpFlex, 100, 1, false)
+	} else if p.svTable.HasFocus() {
+		p.helpFocus = p.svTable
+		p.svFlex.SetDirection(cview.FlexRow)
+		p.svFlex.AddItem(p.helpFlex, 100, 1, false)
+	} else {
+		return
 	}
 
-	app.SetFocus(helpModal)
+	p.helpView.ScrollToBeginning()
+	p.app.SetFocus(p.helpView)
 }
 
-func showSearch() {
-	pages.SwitchToPage("Search page")
-	app.SetFocus(searchInput)
+func (p *Picker) showSearch() {
+	p.pages.SwitchToPage("Search page")
+	p.app.SetFocus(p.searchInput)
 }
 
-func testErr(err error) {
+// Convert function converts a color value into all of the color types without
+// starting the application. The accepted values are the same as the values
+// accepted by the search bar (EX: "#ff8800", "rgb: 255 136 0", "hsv: 32 100
+// 100", "hsl: 32 100 50", "cmyk: 0 47 100 0", or "decimal: 16746496"). The
+// alpha of a hex value with an alpha channel (EX: "#ff8800cc") is kept.
+// Colors are named from the preset colors the same way as in the
+// application, reading the color files once.
+func Convert(input string) (ColorValues, error) {
+	rgb, a, err := parseColorAlpha(input)
 	if err != nil {
-		log.Fatal(err)
-		app.Stop()
+		return ColorValues{}, err
 	}
+
+	c := newRGBColorValues(rgb, filePresetColors(""))
+	c.Alpha = a
+
+	return c, nil
+}
+
+// Config type used to configure how the cpick application is started
+type Config struct {
+	// Screen is an optional screen (such as a tcell.SimulationScreen) that the
+	// application will run against instead of the terminal. The screen must
+	// already be initialized. If no screen is given, a terminal screen is
+	// created automatically.
+	Screen tcell.Screen
+
+	// AfterDraw is an optional function that is called with the screen every
+	// time the application is drawn and shown. Because the screen is cleared when the
+	// application stops, this can be used to check the contents of a
+	// tcell.SimulationScreen while the application is running (EX: for
+	// snapshot tests).
+	AfterDraw func(screen tcell.Screen)
+
+	// Initial is an optional hex value (EX: #3366ff, #36f, or #3366ffcc with
+	// an alpha) of the color that is selected on the saturation-value table
+	// when the application starts. An invalid value is ignored and the
+	// application starts on the hue table.
+	Initial string
+
+	// NoRestore stops the application from starting at the last selected
+	// color, which is saved in ~/.config/cpick/last.json. The last selected
+	// color is only used if no Initial color is given.
+	NoRestore bool
+
+	// ClipboardType is the type used to format colors copied to the clipboard
+	// with y (hex, rgb, hsv, hsl, hwb, cmyk, decimal, ansi, escape, or name).
+	// By default, colors are copied as hex values.
+	ClipboardType string
+
+	// Palette restricts the preset colors to a single palette file in
+	// ~/.config/cpick/palettes (EX: "work" for work.json). By default, the
+	// colors of every palette file are shown after the other preset colors.
+	Palette string
+
+	// Grayscale starts the application on a saturation-value table that only
+	// contains grays. The hue screen is disabled until grayscale mode is
+	// turned off with w.
+	Grayscale bool
+
+	// Step is the amount that the value (with + and -) and the saturation
+	// (with [ and ]) change on the saturation-value table. By default, the
+	// step is 5.
+	Step int
+
+	// SVRows is the number of rows of the saturation-value table, which is
+	// kept between 10 and 100 and limited to the height of the screen. More
+	// rows give finer control over the value: with 100 rows, each row is one
+	// value instead of two. By default, there are 50 rows.
+	SVRows int
+
+	// SingleTint shows only the color that is selected instead of the dark
+	// and light tints of the highlighted cell of the saturation-value table.
+	// Each cell shows two values using a half block, and the light tint is
+	// the one that is selected.
+	SingleTint bool
+
+	// LargePreview starts the application with larger color blocks, which
+	// can be toggled with L.
+	LargePreview bool
+
+	// HueWrap wraps the selection of the hue table around its ends, so that
+	// moving left from a hue of 0 goes to the last hue and moving right from
+	// the last hue goes to 0, like a hue wheel.
+	HueWrap bool
+
+	// NoPresets leaves the preset color panel out of the hue screen, giving
+	// the color blocks its room and skipping building the preset tables.
+	// Colors can't be searched by name without the presets.
+	NoPresets bool
+
+	// NoMouse turns off mouse support. By default, colors can be clicked on
+	// the hue, saturation-value, and preset color tables.
+	NoMouse bool
+
+	// CMYK starts the application on the CMYK page, where the color is
+	// adjusted with cyan, magenta, yellow, and black sliders for print work.
+	// The page can also be shown with K.
+	CMYK bool
+
+	// List starts the application on an alphabetical list of every named
+	// preset color, where a color is picked without the table layout. The
+	// list can also be shown with I.
+	List bool
+
+	// ColorMode is how colors are drawn: truecolor (the default), or 256 to
+	// draw every color as the nearest of the 256 terminal colors on terminals
+	// that don't draw truecolor properly. The mode can be switched with M.
+	// Only how colors are drawn changes, not the returned color values.
+	ColorMode string
+
+	// Simulate is the type of color blindness (protanopia, deuteranopia, or
+	// tritanopia) that is simulated when the application starts. Simulations
+	// only change how colors are drawn, not the returned color values.
+	Simulate string
+
+	// Sandbox keeps the application running when a color is selected on the
+	// saturation-value table, for browsing colors while noting some of them.
+	// Each selected color is shown below the page, added to the recently
+	// selected colors, and passed to SandboxFunc. The application only stops
+	// when it is quit, which returns ErrCanceled.
+	Sandbox bool
+
+	// SandboxFunc is an optional function that is called with every color
+	// selected on the saturation-value table in sandbox mode (EX: to append
+	// the colors to a file). An error returned by the function is shown below
+	// the page.
+	SandboxFunc func(c ColorValues) error
+
+	// Emphasis is the color type (rgb, hsv, hsl, hwb, cmyk, hex, decimal, or
+	// ansi) that is shown in bold at the top of the color values. The
+	// emphasized type is cycled with f and remembered in
+	// ~/.config/cpick/config.json, which is used if no type is given.
+	Emphasis string
+
+	// Theme is the theme of the application: dark (the default), light for
+	// terminals with a light background, or mono to only use black, white,
+	// and grays. If no theme is given, the theme in ~/.config/cpick/config.json
+	// is used.
+	Theme string
 }
 
 // Start function starts the cpick application.
-// Testing (bool) is used to test all of the functions to make sure they
-// can run properly without a need for user input (testing = true).
+//
+// Deprecated: Testing mode has been removed, so testing (bool) must be false.
+// To test the application, pass a tcell.SimulationScreen to StartWithConfig
+// in the Screen field of Config and inject key events into it instead.
 func Start(testing bool) (ColorValues, error) {
 	if testing {
-		// If being run in testing mode, run the tester function
-		testingMode = true
-		tester()
-	} else if !testingMode {
-		// Find the width and height of the application
-		app.Init()
-		width, height := app.GetScreenSize()
-		smallWidth = width < BREAKPOINT_WIDTH
-		smallHeight = height < BREAKPOINT_HEIGHT
+		return ColorValues{}, errors.New("testing mode has been removed, use a tcell.SimulationScreen in the Screen field of Config instead")
+	}
+
+	return StartWithConfig(Config{})
+}
+
+// StartWithConfig function starts the cpick application using the given
+// configuration. If the application is quit without a color being selected,
+// ErrCanceled is returned. It is the same as starting a picker created with
+// NewPicker.
+func StartWithConfig(config Config) (ColorValues, error) {
+	return NewPicker(config).Start()
+}
+
+// Run the picker until count colors are selected or it is quit, and return
+// the last selected color
+func (p *Picker) start(count int) (ColorValues, error) {
+	// cview gives every primitive the styles of the theme when it is created,
+	// so the setup is done while the theme can't be changed by another picker
+	themeMutex.Lock()
+	err := p.setup()
+	themeMutex.Unlock()
+	if err != nil {
+		return ColorValues{}, err
+	}
+	p.pickCount = count
+
+	if err := p.app.Run(); err != nil {
+		return ColorValues{}, err
+	}
+
+	if len(p.pickedColors) == 0 {
+		return ColorValues{}, ErrCanceled
 	}
 
-	app.SetInputCapture(inputCaptureHandler)
+	return p.returnColor, nil
+}
 
-	pages.AddPage("Hue page", hFlex, true, true)
-	pages.AddPage("Saturation-Value page", svFlex, true, false)
-	pages.AddPage("Search page", searchFlex, true, false)
+// Set up the screens of the picker from its configuration
+func (p *Picker) setup() error {
+	config := p.config
 
-	hTableSetup()
-	svTableSetup()
-	colorPageSetup()
-	helpPageSetup()
-	searchInputSetup()
+	// The theme is set before anything is created, so that every primitive
+	// uses its styles
+	themeName := config.Theme
+	if themeName == "" {
+		themeName = loadConfigFile().Theme
+	}
+	p.setTheme(themeName)
 
-	hScreenSetup()
-	svScreenSetup()
+	p.resetState()
 
-	if !testingMode {
-		app.SetRoot(pages, true)
-		if err := app.Run(); err != nil {
-			log.Fatal(err)
-			panic(err)
+	// Read the colors before the screen is set up, so that an invalid color
+	// file doesn't leave the terminal in a broken state
+	p.palette = config.Palette
+	takeColorCacheErr()
+	data, err := getCustomColors(p.palette)
+	if err != nil {
+		return err
+	}
+	p.fileColors = listColors(data)
+	p.colorCacheErr = takeColorCacheErr()
+
+	// Run the application against the given screen, or create a terminal
+	// screen
+	screen := config.Screen
+	if screen == nil {
+		if screen, err = tcell.NewScreen(); err != nil {
+			return err
+		}
+		if err := screen.Init(); err != nil {
+			return err
 		}
 	}
 
-	return returnColor, nil
+	// Check for truecolor before the tables are drawn, so that users of
+	// limited terminals can be pointed to the 256 color mode
+	p.truecolorTerminal = supportsTruecolor(screen)
+
+	// Pastes are caught by the screen, so that pasted text is added to a
+	// field at once
+	p.app.SetScreen(newPasteScreen(screen))
+	width, height := screen.Size()
+	p.setScreenSize(width, height)
+
+	// The application only learns about the size of a given screen through a
+	// resize event
+	p.app.QueueEvent(tcell.NewEventResize(width, height))
+
+	if config.Step > 0 {
+		p.svStep = config.Step
+	}
+
+	p.singleTint = config.SingleTint
+	p.hueWrap = config.HueWrap
+	p.noPresets = config.NoPresets
+	p.sandbox, p.sandboxFunc = config.Sandbox, config.SandboxFunc
+
+	emphasisName := config.Emphasis
+	if emphasisName == "" {
+		emphasisName = loadConfigFile().Emphasis
+	}
+	p.emphasis = getEmphasis(emphasisName)
+
+	if config.SVRows > 0 {
+		p.setSVRows(config.SVRows)
+	}
+
+	p.keybindings = loadKeybindings(loadConfigFile())
+
+	if config.ClipboardType != "" {
+		p.clipboardType = config.ClipboardType
+	}
+
+	p.simulation = getSimulation(config.Simulate)
+	p.colorMode = getColorMode(config.ColorMode)
+
+	p.app.SetInputCapture(p.inputCaptureHandler)
+	p.app.SetAfterFocusFunc(p.updateCompactLayout)
+	if config.AfterDraw != nil {
+		p.app.SetAfterDrawFunc(func(screen tcell.Screen) {
+			// Show the drawn contents first, so that a simulation screen
+			// returns them from GetContents
+			screen.Show()
+
+			// Pass on the given screen instead of the screen that wraps it
+			if s, ok := screen.(*pasteScreen); ok {
+				screen = s.Screen
+			}
+			config.AfterDraw(screen)
+		})
+	}
+
+	p.pages.AddPage("Hue page", p.hFlex, true, true)
+	p.pages.AddPage("Saturation-Value page", p.svFlex, true, false)
+	p.pages.AddPage("Search page", p.searchFlex, true, false)
+	p.pages.AddPage("Harmony page", p.harmonyFlex, true, false)
+	p.pages.AddPage("256 Color page", p.palette256Flex, true, false)
+	p.pages.AddPage("16 Color page", p.palette16Flex, true, false)
+	p.pages.AddPage("Slider page", p.sliderFlex, true, false)
+	p.pages.AddPage("Gradient page", p.gradientFlex, true, false)
+	p.pages.AddPage("CMYK page", p.cmykFlex, true, false)
+	p.pages.AddPage("Favorites page", p.favoritesFlex, true, false)
+	p.pages.AddPage("Save page", p.saveFlex, true, false)
+	p.pages.AddPage("Named Color page", p.namedFlex, true, false)
+	p.app.SetFocus(p.hTable)
+
+	p.hTableSetup()
+	p.svTableSetup()
+	if p.noPresets {
+		p.clearColorPages()
+	} else {
+		p.colorPageSetup(data)
+	}
+	p.helpPageSetup()
+	p.searchInputSetup()
+	p.hexInputSetup()
+	p.hueInputSetup()
+	p.filterInputSetup()
+	p.historySetup()
+	p.harmonySetup()
+	p.palette256Setup()
+	p.palette16Setup()
+	p.sliderSetup()
+	p.gradientSetup()
+	p.cmykSetup()
+	p.favoritesSetup()
+	p.saveSetup()
+	p.namedListSetup()
+	p.rampSetup()
+	p.errorModalSetup()
+	p.statusSetup()
+
+	p.hScreenSetup()
+	p.svScreenSetup()
+
+	// Start at the last selected color if no initial color is given
+	initial := config.Initial
+	if initial == "" && !config.NoRestore {
+		initial = loadLastColor()
+	}
+
+	if initial != "" {
+		if hex, a, ok := parseHexAlpha(initial); ok {
+			p.alpha = a
+			p.selectSVColor(color.HextoHSV(color.Hex(hex)))
+		}
+	}
+
+	if config.Grayscale {
+		p.setGrayscale(true)
+	}
+
+	if config.CMYK {
+		p.toggleCMYK()
+	}
+
+	if config.List {
+		p.toggleNamedList()
+	}
+
+	// The selections made while setting up can't be undone, and the undo
+	// history starts at the color the saturation-value table starts at
+	p.clearSelections()
+	row, column := p.svTable.GetSelection()
+	p.recordSelection(row, column)
+
+	p.warnTruecolor()
+	p.warnColorCache()
+
+	p.setLargePreview(config.LargePreview)
+	p.app.EnableMouse(!config.NoMouse)
+
+	// Setting the root changes the focus, so keep the focus of the initial
+	// color or grayscale mode
+	focus := p.app.GetFocus()
+	p.app.SetRoot(p.statusFlex, true)
+	p.app.SetFocus(focus)
+
+	return nil
 }
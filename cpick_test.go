@@ -1,19 +1,2348 @@
 package cpick_test
 
 import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
 	"testing"
+	"time"
+	"unicode/utf16"
+	"unicode/utf8"
 
+	color "github.com/ethanbaker/colors"
 	"github.com/ethanbaker/cpick"
+	"github.com/gdamore/tcell/v2"
 )
 
 func Test_Start(t *testing.T) {
-	_, err := cpick.Start(true)
-	//_, err := cpick.Start(false) // For developing
+	// Testing mode has been removed in favor of a simulation screen
+	if _, err := cpick.Start(true); err == nil || !strings.Contains(err.Error(), "SimulationScreen") {
+		t.Errorf("Start is not properly pointing to a simulation screen in place of testing mode!\nOutput: %v\n", err)
+	}
+}
+
+// Create a simulation screen of the breakpoint size with keys waiting to be
+// read. Each key is a tcell.Key, a rune, or a string of runes.
+func newTestScreen(t *testing.T, keys ...interface{}) tcell.SimulationScreen {
+	t.Helper()
+
+	screen := tcell.NewSimulationScreen("UTF-8")
+	if err := screen.Init(); err != nil {
+		t.Fatal(err)
+	}
+	screen.SetSize(cpick.BREAKPOINT_WIDTH, cpick.BREAKPOINT_HEIGHT)
+
+	for _, k := range keys {
+		switch k := k.(type) {
+		case tcell.Key:
+			screen.InjectKey(k, 0, tcell.ModNone)
+		case rune:
+			screen.InjectKey(tcell.KeyRune, k, tcell.ModNone)
+		case string:
+			screen.InjectKeyBytes([]byte(k))
+		default:
+			t.Fatalf("%v is not a key", k)
+		}
+	}
+
+	return screen
+}
+
+// Start cpick on a test screen that is sent the given keys and return the
+// selected color
+func startWithKeys(t *testing.T, config cpick.Config, keys ...interface{}) cpick.ColorValues {
+	t.Helper()
+
+	config.Screen = newTestScreen(t, keys...)
+	c, err := cpick.StartWithConfig(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return c
+}
+
+// How long a test picker waits for the screen to be drawn or the picker to stop
+const testTimeout = 10 * time.Second
+
+// A picker running on a test screen in the background. Keys are sent one at a
+// time, and every key is drawn before the next one is sent, so the screen can
+// be checked between keys.
+type testPicker struct {
+	t      *testing.T
+	screen tcell.SimulationScreen
+	drawn  chan []tcell.SimCell
+	done   chan testResult
+
+	// The contents of the last drawn screen
+	cells []tcell.SimCell
+	width int
+}
+
+type testResult struct {
+	c   cpick.ColorValues
+	err error
+}
+
+// Start a picker on a test screen of the given size (or the breakpoint size
+// if the size is 0) and wait for it to be drawn
+func startTestPicker(t *testing.T, config cpick.Config, width int, height int) *testPicker {
+	t.Helper()
+
+	p := &testPicker{
+		t:      t,
+		screen: newTestScreen(t),
+		drawn:  make(chan []tcell.SimCell, 100),
+		done:   make(chan testResult, 1),
+	}
+	if width > 0 && height > 0 {
+		p.screen.SetSize(width, height)
+	}
+	p.width, _ = p.screen.Size()
+
+	// A simulation screen only has 256 colors, so the warning about truecolor
+	// would be shown on every test screen unless COLORTERM is set
+	if _, ok := os.LookupEnv("COLORTERM"); !ok {
+		t.Setenv("COLORTERM", "truecolor")
+	}
+
+	// Copy the contents of every drawn screen, since the screen is cleared
+	// once the application stops
+	config.Screen = p.screen
+	config.AfterDraw = func(s tcell.Screen) {
+		contents, _, _ := s.(tcell.SimulationScreen).GetContents()
+
+		cells := make([]tcell.SimCell, len(contents))
+		for i, cell := range contents {
+			cells[i] = cell
+			cells[i].Runes = append([]rune(nil), cell.Runes...)
+		}
+
+		select {
+		case p.drawn <- cells:
+		default:
+		}
+	}
+
+	go func() {
+		c, err := cpick.StartWithConfig(config)
+		p.done <- testResult{c, err}
+	}()
+
+	// Stop the picker if the test ends early, so that it doesn't take the
+	// keys of the next test
+	t.Cleanup(func() {
+		if p.done == nil {
+			return
+		}
+		p.screen.PostEventWait(tcell.NewEventKey(tcell.KeyCtrlC, 0, tcell.ModNone))
+		select {
+		case <-p.done:
+		case <-time.After(testTimeout):
+		}
+	})
+
+	// The screen is drawn once when the application starts and once when it
+	// learns the size of the screen
+	p.wait()
+	p.wait()
+
+	return p
+}
+
+// Wait for the screen to be drawn
+func (p *testPicker) wait() {
+	p.t.Helper()
+
+	select {
+	case p.cells = <-p.drawn:
+	case r := <-p.done:
+		p.done = nil
+		p.t.Fatalf("The picker stopped before the screen was drawn!\nOutput: %v, %v\n", r.c, r.err)
+	case <-time.After(testTimeout):
+		p.t.Fatal("The picker is not drawing the screen!\n")
+	}
+}
+
+// Turn a key into events. Each key is a tcell.Key, a rune, a string of
+// runes, or a tcell.Event.
+func testEvents(t *testing.T, key interface{}) []tcell.Event {
+	t.Helper()
+
+	switch k := key.(type) {
+	case tcell.Key:
+		return []tcell.Event{tcell.NewEventKey(k, 0, tcell.ModNone)}
+	case rune:
+		return []tcell.Event{tcell.NewEventKey(tcell.KeyRune, k, tcell.ModNone)}
+	case string:
+		var events []tcell.Event
+		for _, r := range k {
+			events = append(events, tcell.NewEventKey(tcell.KeyRune, r, tcell.ModNone))
+		}
+		return events
+	case tcell.Event:
+		return []tcell.Event{k}
+	}
+
+	t.Fatalf("%v is not a key", key)
+	return nil
+}
+
+// Send keys to the picker, waiting for each one to be drawn, and return the
+// text of the screen
+func (p *testPicker) press(keys ...interface{}) string {
+	p.t.Helper()
+
+	for _, k := range keys {
+		for _, event := range testEvents(p.t, k) {
+			p.screen.PostEventWait(event)
+			p.wait()
+		}
+	}
+
+	return p.text()
+}
+
+// Send mouse events to the picker and return the text of the screen. A mouse
+// event can draw the screen more than once, so the screen is read once no more
+// drawing happens for a moment.
+func (p *testPicker) mouse(events ...*tcell.EventMouse) string {
+	p.t.Helper()
+
+	for _, event := range events {
+		p.screen.PostEventWait(event)
+	}
+	p.wait()
+
+	for {
+		select {
+		case p.cells = <-p.drawn:
+		case <-time.After(50 * time.Millisecond):
+			return p.text()
+		}
+	}
+}
+
+// Click the left mouse button at a position on the screen
+func (p *testPicker) click(x int, y int) string {
+	p.t.Helper()
+
+	return p.mouse(tcell.NewEventMouse(x, y, tcell.Button1, tcell.ModNone), tcell.NewEventMouse(x, y, tcell.ButtonNone, tcell.ModNone))
+}
+
+// Send the keys that stop the picker and return the selected color
+func (p *testPicker) finish(keys ...interface{}) (cpick.ColorValues, error) {
+	p.t.Helper()
+
+	for _, k := range keys {
+		for _, event := range testEvents(p.t, k) {
+			p.screen.PostEventWait(event)
+		}
+	}
+
+	select {
+	case r := <-p.done:
+		p.done = nil
+		return r.c, r.err
+	case <-time.After(testTimeout):
+		p.t.Fatalf("The picker is not stopping!\nOutput: %v\n", p.text())
+	}
+
+	return cpick.ColorValues{}, nil
+}
+
+// Get the text of a line of the last drawn screen
+func (p *testPicker) line(y int) string {
+	var b strings.Builder
+	for _, cell := range p.cells[y*p.width : (y+1)*p.width] {
+		if len(cell.Runes) > 0 {
+			b.WriteRune(cell.Runes[0])
+		} else {
+			b.WriteRune(' ')
+		}
+	}
+
+	return b.String()
+}
+
+// Get the text of the last drawn screen
+func (p *testPicker) text() string {
+	lines := make([]string, len(p.cells)/p.width)
+	for y := range lines {
+		lines[y] = p.line(y)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// Find the position of text on the last drawn screen
+func (p *testPicker) find(text string) (int, int, bool) {
+	for y := 0; y < len(p.cells)/p.width; y++ {
+		line := p.line(y)
+		if i := strings.Index(line, text); i >= 0 {
+			return utf8.RuneCountInString(line[:i]), y, true
+		}
+	}
+
+	return 0, 0, false
+}
+
+// Get the style of a cell of the last drawn screen
+func (p *testPicker) style(x int, y int) tcell.Style {
+	return p.cells[y*p.width+x].Style
+}
+
+// Move HOME and the working directory to temporary directories, so that only
+// the preset colors are read instead of any colors.json or palette files
+func isolateFiles(t *testing.T) {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+}
+
+func Test_StartWithScreen(t *testing.T) {
+	// Keep the selection from being saved to the real history file
+	t.Setenv("HOME", t.TempDir())
+
+	// Switch to the saturation-value table and select the top right color
+	c := startWithKeys(t, cpick.Config{}, tcell.KeyTab, tcell.KeyEnter)
+
+	if c.Hex != "ff0000" {
+		t.Errorf("StartWithConfig is not properly returning the selected color!\nOutput: %v\n", c)
+	}
+}
+
+func Test_StartSnapshot(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	// Keep the text of every drawn screen, since the screen is cleared once
+	// the application stops
+	var snapshots []string
+	afterDraw := func(s tcell.Screen) {
+		cells, width, _ := s.(tcell.SimulationScreen).GetContents()
+
+		var b strings.Builder
+		for i, cell := range cells {
+			if i > 0 && i%width == 0 {
+				b.WriteRune('\n')
+			}
+			if len(cell.Runes) > 0 {
+				b.WriteRune(cell.Runes[0])
+			}
+		}
+		snapshots = append(snapshots, b.String())
+	}
+
+	// Switch to the saturation-value table and select the top right color
+	c := startWithKeys(t, cpick.Config{NoRestore: true, AfterDraw: afterDraw}, tcell.KeyTab, tcell.KeyEnter)
+
+	if c.Hex != "ff0000" {
+		t.Errorf("StartWithConfig is not properly returning the selected color!\nOutput: %v\n", c)
+	}
+
+	if len(snapshots) == 0 {
+		t.Fatal("StartWithConfig is not properly calling AfterDraw!\n")
+	}
+
+	// The hue screen is drawn before the saturation-value screen
+	hueScreen := -1
+	for i, s := range snapshots {
+		if strings.Contains(s, "Press ` to see help") {
+			hueScreen = i
+			break
+		}
+	}
+	if hueScreen < 0 {
+		t.Fatalf("StartWithConfig is not properly drawing the hue screen!\nOutput: %v\n", snapshots[0])
+	}
+
+	// The saturation-value screen shows the top right color after it
+	svScreen := false
+	for _, s := range snapshots[hueScreen+1:] {
+		if strings.Contains(s, "H:  0 S:100 V:100") {
+			svScreen = true
+			break
+		}
+	}
+	if !svScreen {
+		t.Errorf("StartWithConfig is not properly drawing the saturation-value screen!\nOutput: %v\n", snapshots[len(snapshots)-1])
+	}
+}
+
+func Test_StartBottomHalf(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	// Select the bottom half of the top right cell of the saturation-value
+	// table
+	c := startWithKeys(t, cpick.Config{NoRestore: true}, tcell.KeyTab, 't', tcell.KeyEnter)
+
+	if c.HSV.V != 99 || c.Hex != "fc0000" {
+		t.Errorf("StartWithConfig is not properly returning the bottom half of the cell!\nOutput: %v\n", c)
+	}
+}
+
+func Test_Picker(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	// Start two pickers at the same time, each with its own screen
+	results := make(chan cpick.ColorValues, 2)
+	for i := 0; i < 2; i++ {
+		// Select the top right color of the saturation-value table
+		screen := newTestScreen(t, tcell.KeyTab, tcell.KeyEnter)
+
+		picker := cpick.NewPicker(cpick.Config{Screen: screen, NoRestore: true})
+		go func() {
+			c, err := picker.Start()
+			if err != nil {
+				t.Error(err)
+			}
+			results <- c
+		}()
+	}
+
+	for i := 0; i < 2; i++ {
+		if c := <-results; c.Hex != "ff0000" {
+			t.Errorf("Picker is not properly returning the selected color!\nOutput: %v\n", c)
+		}
+	}
+}
+
+func Test_PickerIndependent(t *testing.T) {
+	isolateFiles(t)
+
+	// Run two pickers at the same time and move only the second one
+	first := startTestPicker(t, cpick.Config{NoRestore: true}, 0, 0)
+	second := startTestPicker(t, cpick.Config{NoRestore: true}, 0, 0)
+	first.press(tcell.KeyTab)
+	second.press(tcell.KeyTab, 'j', 'j')
+
+	// Test that the first picker has nothing to undo
+	if text := first.press('u'); !strings.Contains(text, "H:  0 S:100 V:100") {
+		t.Errorf("The pickers are not properly keeping their own selections!\nOutput: %v\n", text)
+	}
+
+	if c, err := second.finish(tcell.KeyEnter); err != nil || c.Hex != "f50000" {
+		t.Errorf("The second picker is not properly returning its color!\nOutput: %v, %v\n", c, err)
+	}
+	if c, err := first.finish(tcell.KeyEnter); err != nil || c.Hex != "ff0000" {
+		t.Errorf("The first picker is not properly returning its color!\nOutput: %v, %v\n", c, err)
+	}
+}
+
+func Test_Convert(t *testing.T) {
+	var inputs = [...]string{"#ff8800", "ff8800", "#f80", "F80", "rgb: 255 136 0", "hsv: 32 100 100", "decimal: 16746496"}
+	for _, v := range inputs {
+		c, err := cpick.Convert(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if c.Hex != "ff8800" || c.RGB.G != 136 || c.Alpha != 1 {
+			t.Errorf("Convert(%v) is not properly converting the color!\nOutput: %v\n", v, c)
+		}
+	}
+
+	var alphaInputs = map[string]float64{"#ff8800cc": 0.8, "ff880080": 0.502, "#ff8800ff": 1, "#ff8800": 1}
+	for v, a := range alphaInputs {
+		c, err := cpick.Convert(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if c.Hex != "ff8800" || c.Alpha != a {
+			t.Errorf("Convert(%v) is not properly reading the alpha channel!\nOutput: %v\n", v, c)
+		}
+	}
+
+	if c, err := cpick.Convert("rgb: 255 0 0"); err != nil || c.Name != "red" {
+		t.Errorf("Convert is not properly naming the color!\nOutput: %v\n", c)
+	}
+
+	var invalidInputs = [...]string{"#ff880", "#ff88", "#ff88000", "#ff8800c", "#ff8800cg", "ff880", "#f8", "rgb: 255 136", "hsv: 360 0 0", "cmyk: a 0 0 0", "ansi: 0", "red", "foo: 0"}
+	for _, v := range invalidInputs {
+		if _, err := cpick.Convert(v); err == nil {
+			t.Errorf("Convert(%v) is not properly returning an error!\n", v)
+		}
+	}
+}
+
+func Test_HSVtoHWB(t *testing.T) {
+	var inputs = map[string]cpick.HWB{"#996633": {H: 30, W: 20, B: 40}, "#ff8800": {H: 32, W: 0, B: 0}, "#ffffff": {H: 0, W: 100, B: 0}, "#000000": {H: 0, W: 0, B: 100}}
+	for v, hwb := range inputs {
+		c, err := cpick.Convert(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if c.HWB != hwb || cpick.HSVtoHWB(c.HSV) != hwb {
+			t.Errorf("HSVtoHWB(%v) is not properly converting the color!\nOutput: %v\n", v, c.HWB)
+		}
+	}
+}
+
+func Test_ColorValuesJSON(t *testing.T) {
+	c, err := cpick.Convert("#ff8800cc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	raw, err := json.Marshal(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{"rgb":{"r":255,"g":136,"b":0},"hsv":{"h":32,"s":100,"v":100},"hsl":{"h":32,"s":100,"l":50},"hwb":{"h":32,"w":0,"b":0},"cmyk":{"c":0,"m":47,"y":100,"k":0},"hex":"ff8800","decimal":16746496,`
+	if !strings.HasPrefix(string(raw), expected) || !strings.Contains(string(raw), `"alpha":0.8,"colorIndex":`) {
+		t.Errorf("MarshalJSON is not properly writing lowercase keys!\nOutput: %v\n", string(raw))
+	}
+
+	var back cpick.ColorValues
+	if err := json.Unmarshal(raw, &back); err != nil {
+		t.Fatal(err)
+	}
+	if back != c {
+		t.Errorf("UnmarshalJSON is not properly reading the color values back!\nOutput: %v\n", back)
+	}
+}
+
+func Test_Kelvin(t *testing.T) {
+	var inputs = map[int]color.RGB{1000: {R: 255, G: 68, B: 0}, 2700: {R: 255, G: 167, B: 87}, 6500: {R: 255, G: 254, B: 250}}
+	for k, rgb := range inputs {
+		if c := cpick.KelvintoRGB(k); c != rgb {
+			t.Errorf("KelvintoRGB(%v) is not properly converting the temperature!\nOutput: %v\n", k, c)
+		}
+	}
+
+	// Test getting the temperatures back, including from darker shades
+	for k := 1500; k <= 9000; k += 500 {
+		rgb := cpick.KelvintoRGB(k)
+		dark := color.RGB{R: rgb.R / 2, G: rgb.G / 2, B: rgb.B / 2}
+		if n := cpick.RGBtoKelvin(rgb); n != k {
+			t.Errorf("RGBtoKelvin(%v) is not properly finding the temperature %v!\nOutput: %v\n", rgb, k, n)
+		}
+		if n := cpick.RGBtoKelvin(dark); n < k-200 || n > k+200 {
+			t.Errorf("RGBtoKelvin(%v) is not properly finding the temperature %v of a darker shade!\nOutput: %v\n", dark, k, n)
+		}
+	}
+
+	if c, err := cpick.Convert("kelvin: 6500"); err != nil || c.Hex != "fffefa" {
+		t.Errorf("Convert is not properly converting a color temperature!\nOutput: %v, %v\n", c, err)
+	}
+	for _, v := range [...]string{"kelvin: 999", "kelvin: 40001", "kelvin: 6500 1"} {
+		if _, err := cpick.Convert(v); err == nil {
+			t.Errorf("Convert(%v) is not properly returning an error!\n", v)
+		}
+	}
+}
+
+func Test_Gradient(t *testing.T) {
+	start, err := cpick.Convert("#000000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	end, err := cpick.Convert("#ffffff")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var hexes []string
+	for _, c := range cpick.Gradient(start, end, 5) {
+		hexes = append(hexes, string(c.Hex))
+	}
+	if strings.Join(hexes, " ") != "000000 404040 808080 bfbfbf ffffff" {
+		t.Errorf("Gradient is not properly interpolating the colors!\nOutput: %v\n", hexes)
+	}
+
+	if g := cpick.Gradient(start, end, 1); len(g) != 1 || g[0].Hex != "000000" {
+		t.Errorf("Gradient is not properly returning a single color!\nOutput: %v\n", g)
+	}
+	if g := cpick.Gradient(start, end, 0); len(g) != 0 {
+		t.Errorf("Gradient is not properly returning no colors!\nOutput: %v\n", g)
+	}
+}
+
+func Test_ExportGPL(t *testing.T) {
+	isolateFiles(t)
+
+	var b bytes.Buffer
+	if err := cpick.ExportGPL(&b); err != nil {
+		t.Fatal(err)
+	}
+
+	s := b.String()
+	if !strings.HasPrefix(s, "GIMP Palette\nName: cpick\n") {
+		t.Errorf("ExportGPL is not properly writing the palette header!\n")
+	}
+	if !strings.Contains(s, "240 248 255\taliceblue\n") {
+		t.Errorf("ExportGPL is not properly writing the preset colors!\n")
+	}
+
+	// Test writing the lists of a color file, with a comment before each list
+	writeConfigFile(t, "colors.json", exportColors)
+	b.Reset()
+	if err := cpick.ExportGPL(&b); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "GIMP Palette\nName: cpick\nColumns: 9\n#\n# blues\n 51 102 255\tmyblue\n# reds\n255   0   0\trød\n128   0   0\tdark red\n"
+	if b.String() != expected {
+		t.Errorf("ExportGPL is not properly writing the palette!\nOutput: %v\n", b.String())
+	}
+}
+
+// Color lists of a color file that is exported
+const exportColors = `{"colorList": [
+	{"name": "blues", "colors": [{"name": "myblue", "value": "#3366ff"}]},
+	{"name": "reds", "colors": [{"name": "rød", "value": "ff0000"}, {"name": "dark red", "value": "#800000"}]}
+]}`
+
+func Test_ExportASE(t *testing.T) {
+	isolateFiles(t)
+
+	var b bytes.Buffer
+	if err := cpick.ExportASE(&b); err != nil {
+		t.Fatal(err)
+	}
+
+	// Header: signature, version 1.0, and the number of blocks
+	data := b.Bytes()
+	if len(data) < 12 || !bytes.Equal(data[:8], []byte{'A', 'S', 'E', 'F', 0, 1, 0, 0}) {
+		t.Fatalf("ExportASE is not properly writing the header!\nOutput: %v\n", data)
+	}
+	if count := binary.BigEndian.Uint32(data[8:12]); count == 0 {
+		t.Errorf("ExportASE is not properly writing the preset colors!\nOutput: %v\n", count)
+	}
+
+	// Test writing the colors of a color file
+	writeConfigFile(t, "colors.json", exportColors)
+	b.Reset()
+	if err := cpick.ExportASE(&b); err != nil {
+		t.Fatal(err)
+	}
+	data = b.Bytes()
+	if len(data) < 12 || !bytes.Equal(data[:12], []byte{'A', 'S', 'E', 'F', 0, 1, 0, 0, 0, 0, 0, 3}) {
+		t.Fatalf("ExportASE is not properly writing the header!\nOutput: %v\n", data)
+	}
+
+	// The first block is a color entry with the name, the RGB color model,
+	// three floats, and the normal color type
+	first := []byte{0, 1, 0, 0, 0, 0x22, 0, 7}
+	for _, r := range "myblue\x00" {
+		first = append(first, 0, byte(r))
+	}
+	first = append(first, 'R', 'G', 'B', ' ')
+	for _, v := range []float32{51.0 / 255, 102.0 / 255, 1} {
+		first = binary.BigEndian.AppendUint32(first, math.Float32bits(v))
+	}
+	first = append(first, 0, 2)
+	if !bytes.HasPrefix(data[12:], first) {
+		t.Errorf("ExportASE is not properly writing the first color block!\nOutput: %v\n", data[12:])
+	}
+
+	// Read the blocks back
+	var names []string
+	var values [][3]float32
+	i := 12
+	for i+6 <= len(data) {
+		length := int(binary.BigEndian.Uint32(data[i+2 : i+6]))
+		block := data[i+6 : i+6+length]
+
+		var name []uint16
+		nameLength := int(binary.BigEndian.Uint16(block[:2]))
+		for j := 0; j < nameLength-1; j++ {
+			name = append(name, binary.BigEndian.Uint16(block[2+j*2:]))
+		}
+		names = append(names, string(utf16.Decode(name)))
+
+		rgb := block[2+nameLength*2+4:]
+		var c [3]float32
+		for j := range c {
+			c[j] = math.Float32frombits(binary.BigEndian.Uint32(rgb[j*4:]))
+		}
+		values = append(values, c)
+
+		i += 6 + length
+	}
+
+	if i != len(data) || strings.Join(names, ", ") != "myblue, rød, dark red" || values[1] != [3]float32{1, 0, 0} {
+		t.Errorf("ExportASE is not properly writing the color blocks!\nOutput: %v %v\n", names, values)
+	}
+}
+
+func Test_StartWithKeybindings(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	// Switch tables with s instead of Tab
+	dir := filepath.Join(home, ".config", "cpick")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "config.json"), []byte(`{"keybindings": {"switch-table": ["s"]}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	c := startWithKeys(t, cpick.Config{}, tcell.KeyTab, 's', tcell.KeyEnter)
+
+	if c.Hex != "ff0000" {
+		t.Errorf("StartWithConfig is not properly using the configured keybindings!\nOutput: %v\n", c)
+	}
+}
+
+func Test_StartGrayscale(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	// Move down two rows and select the gray
+	c := startWithKeys(t, cpick.Config{Grayscale: true}, 'j', 'j', tcell.KeyEnter)
+
+	if c.RGB.R != c.RGB.G || c.RGB.G != c.RGB.B || c.HSV.V != 96 || c.Hex == "" {
+		t.Errorf("StartWithConfig is not properly returning a gray in grayscale mode!\nOutput: %v\n", c)
+	}
+}
+
+func Test_StartCMYK(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	// Add 5% cyan to the starting red and select it
+	right := tcell.KeyRight
+	c := startWithKeys(t, cpick.Config{CMYK: true}, right, right, right, right, right, tcell.KeyEnter)
+
+	if c.CMYK.C != 5 || c.CMYK.M != 100 || c.CMYK.Y != 100 || c.CMYK.K != 0 || c.Hex != "f20000" {
+		t.Errorf("StartWithConfig is not properly returning the color of the CMYK page!\nOutput: %v\n", c)
+	}
+}
+
+func Test_StartHueWrap(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	// Move left from the first hue and select the top left color of its table
+	c := startWithKeys(t, cpick.Config{HueWrap: true}, tcell.KeyLeft, tcell.KeyEnter, tcell.KeyEnter)
+
+	if c.HSV.H < 350 {
+		t.Errorf("StartWithConfig is not properly wrapping the hue table!\nOutput: %v\n", c)
+	}
+}
+
+func Test_StartWithPaste(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	screen := newTestScreen(t)
+
+	// A paste has more events than the screen can hold, so they are posted
+	// while the application is running
+	var events []tcell.Event
+	paste := func(text string) {
+		events = append(events, tcell.NewEventPaste(true))
+		for _, r := range text {
+			events = append(events, tcell.NewEventKey(tcell.KeyRune, r, tcell.ModNone))
+		}
+		events = append(events, tcell.NewEventPaste(false))
+	}
+
+	// A paste outside of a field is ignored, so its q doesn't quit
+	paste("quit")
+
+	// Paste a hex value with an alpha channel into the search bar, search for
+	// it, and select it
+	events = append(events, tcell.NewEventKey(tcell.KeyRune, '?', tcell.ModNone))
+	paste("  #ff8800cc\n")
+	events = append(events, tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone))
+
+	go func() {
+		for _, event := range events {
+			screen.PostEventWait(event)
+		}
+	}()
+
+	c, err := cpick.StartWithConfig(cpick.Config{Screen: screen})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c.Hex != "ff8800" || c.Alpha != 0.8 {
+		t.Errorf("StartWithConfig is not properly searching for a pasted color!\nOutput: %v\n", c)
+	}
+}
+
+func Test_StartList(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	// Select the second named color
+	c := startWithKeys(t, cpick.Config{List: true}, 'j', tcell.KeyEnter)
+
+	if c.Name != "antiquewhite" || c.Hex != "faebd7" {
+		t.Errorf("StartWithConfig is not properly returning the color of the named color list!\nOutput: %v\n", c)
+	}
+}
+
+func Test_StartWithHexInput(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	// Type the hex value, go to the saturation-value table, and select it
+	c := startWithKeys(t, cpick.Config{}, '#', "3366ff", tcell.KeyEnter, tcell.KeyEnter)
+
+	if c.Hex != "3366ff" {
+		t.Errorf("StartWithConfig is not properly selecting the typed hex value!\nOutput: %v\n", c)
+	}
+}
+
+func Test_StartCompact(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	// Move one cell to the right on the hue table, then select the most
+	// saturated color
+	screen := newTestScreen(t, 'l', tcell.KeyEnter, tcell.KeyEnter)
+	screen.SetSize(60, 20)
+
+	c, err := cpick.StartWithConfig(cpick.Config{Screen: screen})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c.HSV.H != 6 || c.HSV.S != 100 || c.HSV.V != 100 {
+		t.Errorf("StartWithConfig is not properly scaling the hue table on a small screen!\nOutput: %v\n", c)
+	}
+}
+
+func Test_StartWithRamp(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	// Select the second shade of red
+	screen := newTestScreen(t, tcell.KeyTab, 'T', 'j', tcell.KeyEnter)
+	screen.SetSize(cpick.BREAKPOINT_WIDTH, 45)
+
+	c, err := cpick.StartWithConfig(cpick.Config{Screen: screen})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c.Hex != "cc0000" {
+		t.Errorf("StartWithConfig is not properly selecting a shade!\nOutput: %v\n", c)
+	}
+}
+
+func Test_StartWithInvalidColors(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	// colors.json is read from the working directory first
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "colors.json"), []byte(`{"colorList": [`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+
+	if _, err := cpick.StartWithConfig(cpick.Config{Screen: newTestScreen(t)}); err == nil {
+		t.Errorf("StartWithConfig is not returning an error for an invalid color file!\n")
+	}
+}
+
+func Test_StartWithPalette(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	dir := filepath.Join(home, ".config", "cpick", "palettes")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	palette := `{"colorList": [{"name": "brand", "colors": [{"name": "brandblue", "value": "#3366ff"}]}]}`
+	if err := os.WriteFile(filepath.Join(dir, "work.json"), []byte(palette), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// The palette is listed after the preset colors when exporting
+	var b bytes.Buffer
+	if err := cpick.ExportGPL(&b); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(b.String(), "# work brand\n 51 102 255\tbrandblue\n") {
+		t.Errorf("ExportGPL is not properly including the palette files!\n")
+	}
+
+	// Select the only color of the palette
+	c := startWithKeys(t, cpick.Config{Palette: "work"}, ' ', tcell.KeyEnter, tcell.KeyEnter)
+	if c.Name != "brandblue" {
+		t.Errorf("StartWithConfig is not properly restricting the colors to the palette!\nOutput: %v\n", c)
+	}
+
+	if _, err := cpick.StartWithConfig(cpick.Config{Screen: newTestScreen(t), Palette: "missing"}); err == nil {
+		t.Errorf("StartWithConfig is not returning an error for a missing palette!\n")
+	}
+}
+
+func Test_StartWithColorFile(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	// colors.json is found in the config directory of HOME
+	dir := filepath.Join(home, ".config", "cpick")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	colors := `{"colorList": [{"name": "mine", "colors": [{"name": "myblue", "value": "#3366ff"}]}]}`
+	if err := os.WriteFile(filepath.Join(dir, "colors.json"), []byte(colors), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Select the only color of the file
+	c := startWithKeys(t, cpick.Config{}, ' ', tcell.KeyEnter, tcell.KeyEnter)
+	if c.Name != "myblue" {
+		t.Errorf("StartWithConfig is not properly reading colors.json from HOME!\nOutput: %v\n", c)
+	}
+}
+
+func Test_StartMulti(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	// Select the top right color, then go back to the saturation-value table
+	// and select the color below it
+	screen := newTestScreen(t, tcell.KeyTab, tcell.KeyEnter, tcell.KeyTab, 'j', tcell.KeyEnter)
+
+	colors, err := cpick.StartMulti(cpick.Config{Screen: screen}, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(colors) != 2 || colors[0].Hex != "ff0000" || colors[1].HSV.V != 98 {
+		t.Errorf("StartMulti is not properly returning the selected colors!\nOutput: %v\n", colors)
+	}
+}
+
+func Test_StartWith256Palette(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	// Open the 256 color palette and select the second color
+	c := startWithKeys(t, cpick.Config{}, 'X', 'l', tcell.KeyEnter)
+
+	if c.ColorIndex != 1 || c.Ansi != "\x1b[38;5;1m" || c.Hex != "800000" {
+		t.Errorf("StartWithConfig is not properly returning a color of the 256 color palette!\nOutput: %v\n", c)
+	}
+}
+
+func Test_Ansi16Code(t *testing.T) {
+	var inputs = map[string]int{"#800000": 31, "#ee0000": 91, "#000000": 30, "#f0f0f0": 97}
+	for v, code := range inputs {
+		c, err := cpick.Convert(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got := cpick.Ansi16Code(c); got != code {
+			t.Errorf("Ansi16Code(%v) is not properly returning the closest standard color!\nOutput: %v\n", v, got)
+		}
+	}
+}
+
+func Test_StartWithLastColor(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	// Select a color, then start again at the same color
+	first := startWithKeys(t, cpick.Config{Initial: "#3366ff"}, tcell.KeyEnter)
+	if c := startWithKeys(t, cpick.Config{}, tcell.KeyEnter); c.Hex != first.Hex {
+		t.Errorf("StartWithConfig is not properly restoring the last selected color!\nOutput: %v\n", c)
+	}
+
+	// Test starting without the last selected color
+	if c := startWithKeys(t, cpick.Config{NoRestore: true}, tcell.KeyTab, tcell.KeyEnter); c.Hex != "ff0000" {
+		t.Errorf("StartWithConfig is not properly ignoring the last selected color!\nOutput: %v\n", c)
+	}
+
+	// Test that an invalid file is ignored
+	path := filepath.Join(home, ".config", "cpick", "last.json")
+	if err := os.WriteFile(path, []byte("{"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if c := startWithKeys(t, cpick.Config{}, tcell.KeyTab, tcell.KeyEnter); c.Hex != "ff0000" {
+		t.Errorf("StartWithConfig is not properly ignoring an invalid last color file!\nOutput: %v\n", c)
+	}
+}
+
+func Test_StartWithInitial(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	// Test the shorthand and alpha channel forms of the initial color
+	if c := startWithKeys(t, cpick.Config{Initial: "#36f"}, tcell.KeyEnter); c.Hex != "3366ff" || c.Alpha != 1 {
+		t.Errorf("StartWithConfig is not properly starting at a shorthand initial color!\nOutput: %v\n", c)
+	}
+	if c := startWithKeys(t, cpick.Config{Initial: "3366ffcc"}, tcell.KeyEnter); c.Hex != "3366ff" || c.Alpha != 0.8 {
+		t.Errorf("StartWithConfig is not properly starting at an initial color with an alpha channel!\nOutput: %v\n", c)
+	}
+}
+
+func Test_StartFavoritesError(t *testing.T) {
+	// A directory in place of the favorites file stops the favorites from
+	// saving
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	if err := os.MkdirAll(filepath.Join(home, ".config", "cpick", "favorites.json"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	var shown bool
+	afterDraw := func(s tcell.Screen) {
+		cells, _, _ := s.(tcell.SimulationScreen).GetContents()
+
+		var b strings.Builder
+		for _, cell := range cells {
+			if len(cell.Runes) > 0 {
+				b.WriteRune(cell.Runes[0])
+			}
+		}
+		shown = shown || strings.Contains(b.String(), "Could not save the favorites")
+	}
+
+	startWithKeys(t, cpick.Config{NoRestore: true, AfterDraw: afterDraw}, 'b', tcell.KeyTab, tcell.KeyEnter)
+	if !shown {
+		t.Errorf("StartWithConfig is not properly showing an error when the favorites can't be saved!\n")
+	}
+}
+
+func Test_StartCanceled(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	c, err := cpick.StartWithConfig(cpick.Config{Screen: newTestScreen(t, 'q')})
+	if !errors.Is(err, cpick.ErrCanceled) {
+		t.Errorf("StartWithConfig is not returning ErrCanceled when quit without a selection!\nOutput: %v, %v\n", c, err)
+	}
+}
+
+func Test_StartSandbox(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	// Note the top right color and the one below it, and then quit
+	screen := newTestScreen(t, tcell.KeyTab, tcell.KeyEnter, 'j', tcell.KeyEnter, 'q')
+
+	var noted []string
+	sandboxFunc := func(c cpick.ColorValues) error {
+		noted = append(noted, string(c.Hex))
+		return nil
+	}
+
+	c, err := cpick.StartWithConfig(cpick.Config{Screen: screen, NoRestore: true, Sandbox: true, SandboxFunc: sandboxFunc})
+	if !errors.Is(err, cpick.ErrCanceled) {
+		t.Errorf("StartWithConfig is not returning ErrCanceled when quit in sandbox mode!\nOutput: %v, %v\n", c, err)
+	}
+
+	if strings.Join(noted, " ") != "ff0000 fa0000" {
+		t.Errorf("StartWithConfig is not properly noting the colors in sandbox mode!\nOutput: %v\n", noted)
+	}
+}
+
+func Test_StartNoPresets(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	// Space stays on the hue table without the preset colors, so the top left
+	// color of the red table is selected
+	c := startWithKeys(t, cpick.Config{NoPresets: true}, ' ', tcell.KeyEnter, tcell.KeyEnter)
+
+	if c.Hex != "ff0000" {
+		t.Errorf("StartWithConfig is not properly leaving out the preset colors!\nOutput: %v\n", c)
+	}
+}
+
+// Put clipboard programs on PATH that write the copied text to a file, and
+// return the path of the file
+func fakeClipboard(t *testing.T) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("The clipboard programs are shell scripts")
+	}
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "clipboard")
+	script := "#!/bin/sh\ncat > " + path + "\n"
+	for _, name := range [...]string{"pbcopy", "xclip"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(script), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	t.Setenv("WAYLAND_DISPLAY", "")
+
+	return path
+}
+
+func Test_StartColorPages(t *testing.T) {
+	isolateFiles(t)
+	p := startTestPicker(t, cpick.Config{}, 0, 0)
+
+	// Test moving to the preset colors, which shows the highlighted color
+	if text := p.press(' '); !strings.Contains(text, "Css Pages") || !strings.Contains(text, "HSV: 208°, 6%, 100%") {
+		t.Errorf("Space is not properly moving to the preset colors!\nOutput: %v\n", text)
+	}
+
+	// Test changing pages
+	if text := p.press('C'); strings.Contains(text, "Css Pages") || strings.Contains(text, "aliceblue") {
+		t.Errorf("C is not properly going to the next page!\nOutput: %v\n", text)
+	}
+	if text := p.press('c'); !strings.Contains(text, "Css Pages") {
+		t.Errorf("c is not properly going to the previous page!\nOutput: %v\n", text)
+	}
+
+	// Test that the first page doesn't wrap around
+	if text := p.press('c'); !strings.Contains(text, "Css Pages") {
+		t.Errorf("c is not properly stopping on the first page!\nOutput: %v\n", text)
+	}
+
+	// Test going to the last color, which isn't a blank cell
+	if text := p.press('G'); !strings.Contains(text, "HSV: 80°, 76%, 80%") {
+		t.Errorf("G is not properly going to the last color!\nOutput: %v\n", text)
+	}
+
+	// Test going back to the hue table and to the preset colors again
+	if text := p.press(' '); !strings.Contains(text, "HSV: 0°, 100%, 100%") {
+		t.Errorf("Space is not properly moving back to the hue table!\nOutput: %v\n", text)
+	}
+	p.press(' ', 'g')
+
+	// Test creating a saturation-value table of the preset color and
+	// selecting it
+	c, err := p.finish(tcell.KeyEnter, tcell.KeyEnter)
+	if err != nil || c.Name != "aliceblue" || c.Hex != "f0f8ff" {
+		t.Errorf("Enter is not properly selecting the preset color!\nOutput: %v, %v\n", c, err)
+	}
+}
+
+func Test_StartColorName(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	// The top right color is red, and the color below it isn't a preset color
+	if c := startWithKeys(t, cpick.Config{NoRestore: true}, tcell.KeyTab, tcell.KeyEnter); c.Name != "red" {
+		t.Errorf("StartWithConfig is not properly naming a preset color!\nOutput: %v\n", c)
+	}
+	if c := startWithKeys(t, cpick.Config{NoRestore: true}, tcell.KeyTab, 'j', tcell.KeyEnter); c.Name != "custom color" {
+		t.Errorf("StartWithConfig is not properly naming a color that isn't a preset color!\nOutput: %v\n", c)
+	}
+
+	// Test generated names
+	cpick.GenerateNames = true
+	defer func() { cpick.GenerateNames = false }()
+
+	if c := startWithKeys(t, cpick.Config{NoRestore: true}, tcell.KeyTab, 'j', tcell.KeyEnter); c.Name != "red-fa0000" {
+		t.Errorf("StartWithConfig is not properly generating a name!\nOutput: %v\n", c)
+	}
+	if c := startWithKeys(t, cpick.Config{NoRestore: true}, tcell.KeyTab, tcell.KeyEnter); c.Name != "red" {
+		t.Errorf("StartWithConfig is not properly keeping the preset name!\nOutput: %v\n", c)
+	}
+}
+
+func Test_StartHelp(t *testing.T) {
+	isolateFiles(t)
+
+	// The help is taller than the breakpoint size
+	p := startTestPicker(t, cpick.Config{}, cpick.BREAKPOINT_WIDTH, 140)
+
+	// Test showing the help from the hue table
+	text := p.press('`')
+	if !strings.Contains(text, "Help (j/k to scroll, escape or enter to exit)") || !strings.Contains(text, "Quitting the application: q or Esc") {
+		t.Errorf("` is not properly showing the help!\nOutput: %v\n", text)
+	}
+	if strings.Contains(text, "{") {
+		t.Errorf("The help is not properly filling in the keys!\nOutput: %v\n", text)
+	}
+
+	// Test that escape goes back to the table the help was shown from
+	if text := p.press(tcell.KeyEscape); !strings.Contains(text, "Press ` to see help") {
+		t.Errorf("Escape is not properly hiding the help!\nOutput: %v\n", text)
+	}
+	p.press(tcell.KeyTab, '`')
+	if text := p.press(tcell.KeyEnter); !strings.Contains(text, "H:  0 S:100 V:100") {
+		t.Errorf("Enter is not properly going back to the saturation-value table!\nOutput: %v\n", text)
+	}
+}
+
+func Test_StartSearch(t *testing.T) {
+	isolateFiles(t)
+	p := startTestPicker(t, cpick.Config{}, 0, 0)
+
+	// Test that typing in the search bar doesn't run commands
+	p.press('?')
+	if text := p.press("q"); !strings.Contains(text, "search for: q") {
+		t.Errorf("q is not properly typed into the search bar!\nOutput: %v\n", text)
+	}
+	p.press(tcell.KeyBackspace2)
+
+	// Test invalid values
+	var invalidInputs = [...]string{"rgb:a", "rgb:0 0 -1", "rgb:0 0 0 0", "hsv:-1 0 0", "hsl:0 0 0 0", "cmyk: 0 0 0 -1", "decimal: -1", "ansi:a"}
+	for _, v := range invalidInputs {
+		if text := p.press(v, tcell.KeyEnter); !strings.Contains(text, "search for: "+v) || !strings.Contains(text, "Please enter") {
+			t.Errorf("The search is not properly showing an error for %v!\nOutput: %v\n", v, text)
+		}
+		p.press(tcell.KeyCtrlU)
+	}
+
+	// Test that escape goes back to the hue table
+	if text := p.press(tcell.KeyEscape); !strings.Contains(text, "Press ` to see help") {
+		t.Errorf("Escape is not properly leaving the search!\nOutput: %v\n", text)
+	}
+
+	// Test searching for every type of color value
+	var inputs = map[string]string{"rgb: 0 128 0": "#008000", "hsv: 120 100 50": "#008000", "hsl: 120 100 25": "#008000", "cmyk: 100 0 100 50": "#008000", "decimal: 32768": "#008000", "#008000": "#008000"}
+	for v, hex := range inputs {
+		p.press('?')
+		if text := p.press(v, tcell.KeyEnter); !strings.Contains(text, "H:120 S:100 V: 50") || !strings.Contains(text, hex) {
+			t.Errorf("The search is not properly selecting %v!\nOutput: %v\n", v, text)
+		}
+		p.press(tcell.KeyTab)
+	}
+
+	// Test searching for a name, and cycling through the colors with it. The
+	// first enter takes the highlighted name of the autocomplete list.
+	p.press('?')
+	first := p.press("blue", tcell.KeyEnter, tcell.KeyEnter)
+	if text := p.press('N'); text == first {
+		t.Errorf("N is not properly going to the next color!\nOutput: %v\n", text)
+	}
+	if text := p.press('n'); text != first {
+		t.Errorf("n is not properly going to the previous color!\nOutput: %v\n", text)
+	}
+
+	c, err := p.finish(tcell.KeyEnter, tcell.KeyEnter)
+	if err != nil || c.Name != "blue" {
+		t.Errorf("The search is not properly selecting the found color!\nOutput: %v, %v\n", c, err)
+	}
+}
+
+func Test_StartAlpha(t *testing.T) {
+	isolateFiles(t)
+
+	// Test that the alpha can't go over 1
+	p := startTestPicker(t, cpick.Config{}, 220, 60)
+	if text := p.press(tcell.KeyTab, 'A'); !strings.Contains(text, "Alpha: 1") {
+		t.Errorf("A is not properly keeping the alpha at 1!\nOutput: %v\n", text)
+	}
+
+	if text := p.press('a', 'a'); !strings.Contains(text, "Alpha: 0.8") {
+		t.Errorf("a is not properly decreasing the alpha!\nOutput: %v\n", text)
+	}
+
+	c, err := p.finish(tcell.KeyEnter)
+	if err != nil || c.Alpha != 0.8 {
+		t.Errorf("The alpha is not properly returned!\nOutput: %v, %v\n", c, err)
+	}
+}
+
+func Test_StartClipboard(t *testing.T) {
+	isolateFiles(t)
+	clipboard := fakeClipboard(t)
+
+	// Test copying the highlighted color of each table as each type
+	var types = map[string]string{"": "#ff0000", "hex": "#ff0000", "rgb": "255;0;0", "hsv": "0;100;100", "decimal": "16711680", "name": "red"}
+	for k, v := range types {
+		p := startTestPicker(t, cpick.Config{ClipboardType: k, NoRestore: true}, 0, 0)
+		text := p.press(tcell.KeyTab, 'y')
+
+		copied, err := os.ReadFile(clipboard)
+		if err != nil || string(copied) != v {
+			t.Errorf("y is not properly copying the color as %v!\nOutput: %s, %v\n", k, copied, err)
+		}
+		if !strings.Contains(text, "Copied "+v) {
+			t.Errorf("y is not properly showing the copied color as %v!\nOutput: %v\n", k, text)
+		}
+
+		p.finish('q')
+	}
+
+	// Test copying the escape sequence
+	p := startTestPicker(t, cpick.Config{}, 0, 0)
+	text := p.press('Y')
+	if copied, err := os.ReadFile(clipboard); err != nil || string(copied) != `\033[38;2;255;0;0m` {
+		t.Errorf("Y is not properly copying the escape sequence!\nOutput: %s, %v\n", copied, err)
+	}
+	if !strings.Contains(text, `Copied \033[38;2;255;0;0m`) {
+		t.Errorf("Y is not properly showing the copied escape sequence!\nOutput: %v\n", text)
+	}
+
+	// Test that nothing is copied when no table has focus
+	os.Remove(clipboard)
+	p.press('`', 'y')
+	if _, err := os.Stat(clipboard); err == nil {
+		t.Errorf("y is copying a color when no table has focus!\n")
+	}
+	p.finish(tcell.KeyEscape, 'q')
+
+	// Test showing an error when there is no clipboard program
+	t.Setenv("PATH", t.TempDir())
+	p = startTestPicker(t, cpick.Config{}, 0, 0)
+	text = p.press('y')
+	if !strings.Contains(text, "Could not copy #ff0000: "+cpick.ErrNoClipboard.Error()) {
+		t.Errorf("y is not properly showing that the color could not be copied!\nOutput: %v\n", text)
+	}
+	x, y, _ := p.find("Could not copy")
+	if fg, _, _ := p.style(x, y).Decompose(); fg.Hex() != 0xff0000 {
+		t.Errorf("The error is not properly shown in red!\nOutput: %v\n", fg)
+	}
+}
+
+func Test_StartHistory(t *testing.T) {
+	isolateFiles(t)
+
+	// Select red, the color below it, and red again
+	startWithKeys(t, cpick.Config{NoRestore: true}, tcell.KeyTab, tcell.KeyEnter)
+	startWithKeys(t, cpick.Config{NoRestore: true}, tcell.KeyTab, 'j', tcell.KeyEnter)
+	startWithKeys(t, cpick.Config{NoRestore: true}, tcell.KeyTab, tcell.KeyEnter)
+
+	// Test that the history is saved with the latest color first and without
+	// duplicates
+	path := filepath.Join(os.Getenv("HOME"), ".config", "cpick", "history.json")
+	var history []string
+	if raw, err := os.ReadFile(path); err != nil || json.Unmarshal(raw, &history) != nil || strings.Join(history, " ") != "ff0000 fa0000" {
+		t.Errorf("The history is not properly saving the selected colors!\nOutput: %v, %v\n", history, err)
+	}
+
+	// Test that the history is shown and can be selected from
+	p := startTestPicker(t, cpick.Config{NoRestore: true}, 220, 60)
+	if text := p.text(); !strings.Contains(text, "████ #ff0000") || !strings.Contains(text, "████ #fa0000") {
+		t.Errorf("The history is not properly shown!\nOutput: %v\n", text)
+	}
+	if text := p.press('p', 'l'); !strings.Contains(text, "HSV: 0°, 100%, 98%") {
+		t.Errorf("p is not properly moving to the history!\nOutput: %v\n", text)
+	}
+
+	c, err := p.finish(tcell.KeyEnter)
+	if err != nil || c.Hex != "fa0000" {
+		t.Errorf("Enter is not properly selecting a color of the history!\nOutput: %v, %v\n", c, err)
+	}
+
+	// Test that the history is limited to the latest colors
+	var colors []string
+	for i := 0; i < cpick.HISTORY_SIZE*2; i++ {
+		colors = append(colors, string(color.HSVtoHex(color.HSV{H: i, S: 100, V: 100})))
+	}
+	raw, _ := json.Marshal(colors)
+	if err := os.WriteFile(path, raw, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	startWithKeys(t, cpick.Config{NoRestore: true}, tcell.KeyTab, tcell.KeyEnter)
+	raw, _ = os.ReadFile(path)
+	if err := json.Unmarshal(raw, &history); err != nil || len(history) != cpick.HISTORY_SIZE || history[0] != "ff0000" {
+		t.Errorf("The history is not properly limited!\nOutput: %v, %v\n", history, err)
+	}
+}
+
+func Test_StartHarmonies(t *testing.T) {
+	isolateFiles(t)
+	p := startTestPicker(t, cpick.Config{NoRestore: true}, 0, 0)
+
+	// Test showing the complementary and analogous colors of red
+	text := p.press('H')
+	if !strings.Contains(text, "Color harmonies of #ff0000") || !strings.Contains(text, "#00ffff") || !strings.Contains(text, "#ff0080") {
+		t.Errorf("H is not properly showing the color harmonies!\nOutput: %v\n", text)
+	}
+
+	// Test going back to the hue table
+	if text := p.press(tcell.KeyEscape); !strings.Contains(text, "Press ` to see help") {
+		t.Errorf("Escape is not properly hiding the color harmonies!\nOutput: %v\n", text)
+	}
+
+	// Test selecting the complementary color
+	p.press('H', 'l', tcell.KeyEnter)
+	c, err := p.finish(tcell.KeyEnter)
+	if err != nil || c.Hex != "00ffff" {
+		t.Errorf("Enter is not properly selecting a color harmony!\nOutput: %v, %v\n", c, err)
+	}
+}
+
+func Test_StartSimulation(t *testing.T) {
+	isolateFiles(t)
+	p := startTestPicker(t, cpick.Config{NoRestore: true}, 220, 60)
+	// The first cell of the hue table is highlighted, so the second one is
+	// checked
+	before := p.style(1, 0)
+
+	// Test simulating protanopia
+	if text := p.press('V'); !strings.Contains(text, "Simulating protanopia") {
+		t.Errorf("V is not properly simulating protanopia!\nOutput: %v\n", text)
+	}
+	if p.style(1, 0) == before {
+		t.Errorf("The simulation is not properly changing the colors!\nOutput: %v\n", p.style(1, 0))
+	}
+
+	// Test that the simulations wrap around
+	if text := p.press('V', 'V', 'V'); strings.Contains(text, "Simulating") || p.style(1, 0) != before {
+		t.Errorf("V is not properly turning off the simulation!\nOutput: %v\n", text)
+	}
+	p.finish('q')
+
+	// Test starting with a simulation, which doesn't change the selected color
+	p = startTestPicker(t, cpick.Config{NoRestore: true, Simulate: "deuteranopia"}, 220, 60)
+	if text := p.text(); !strings.Contains(text, "Simulating deuteranopia") {
+		t.Errorf("StartWithConfig is not properly starting with a simulation!\nOutput: %v\n", text)
+	}
+
+	c, err := p.finish(tcell.KeyTab, tcell.KeyEnter)
+	if err != nil || c.Hex != "ff0000" {
+		t.Errorf("The simulation is changing the selected color!\nOutput: %v, %v\n", c, err)
+	}
+}
+
+func Test_StartContrast(t *testing.T) {
+	isolateFiles(t)
+	p := startTestPicker(t, cpick.Config{NoRestore: true}, 220, 60)
+
+	// Test searching for a contrast reference
+	text := p.press('?', "bg: #ffffff", tcell.KeyEnter)
+	if !strings.Contains(text, "Contrast vs #ffffff: 4.00:1") || !strings.Contains(text, "AA: Fail") {
+		t.Errorf("The search is not properly setting the contrast reference!\nOutput: %v\n", text)
+	}
+
+	// Test an invalid contrast reference
+	if text := p.press('?', "bg: #zzzzzz", tcell.KeyEnter); !strings.Contains(text, "Please enter") {
+		t.Errorf("The search is not properly showing an error for an invalid contrast reference!\nOutput: %v\n", text)
+	}
+
+	// Test clearing the contrast reference
+	p.press(tcell.KeyCtrlU)
+	if text := p.press("bg:", tcell.KeyEnter); strings.Contains(text, "Contrast vs") {
+		t.Errorf("The search is not properly clearing the contrast reference!\nOutput: %v\n", text)
+	}
+
+	// Test the best text color and the sample text of navy
+	text = p.press('?', "#000080", tcell.KeyEnter)
+	if !strings.Contains(text, "Best text: ") || !strings.Contains(text, "#ffffff") {
+		t.Errorf("The best text color is not properly shown!\nOutput: %v\n", text)
+	}
+	if _, _, ok := p.find(" Sample"); !ok {
+		t.Errorf("The sample text is not properly shown!\nOutput: %v\n", text)
+	}
+}
+
+// Write a file to the cpick config directory of HOME
+func writeConfigFile(t *testing.T, name string, data string) string {
+	t.Helper()
+
+	dir := filepath.Join(os.Getenv("HOME"), ".config", "cpick")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func Test_StartRemappedKeys(t *testing.T) {
+	isolateFiles(t)
+	clipboard := fakeClipboard(t)
+	writeConfigFile(t, "config.json", `{"keybindings": {"quit": "Ctrl-Q", "search": ["/"], "copy": "Ctrl-Y"}}`)
+
+	// Test that the help shows the remapped keys
+	p := startTestPicker(t, cpick.Config{NoRestore: true}, cpick.BREAKPOINT_WIDTH, 140)
+	text := p.press('`')
+	if !strings.Contains(text, "Quitting the application: Ctrl-Q") || !strings.Contains(text, "to the clipboard: Ctrl-Y") {
+		t.Errorf("The help is not properly showing the keybindings!\nOutput: %v\n", text)
+	}
+
+	// Test that a remapped action only uses its new key
+	p.press(tcell.KeyEscape, 'y')
+	if _, err := os.ReadFile(clipboard); err == nil {
+		t.Errorf("y is not properly replaced by the remapped copy key!\n")
+	}
+	p.press(tcell.KeyCtrlY)
+	if copied, err := os.ReadFile(clipboard); err != nil || string(copied) != "#ff0000" {
+		t.Errorf("Ctrl-Y is not properly copying the color!\nOutput: %s, %v\n", copied, err)
+	}
+
+	// Test that the default keys are replaced, and that keys which aren't
+	// remapped keep their defaults
+	if text := p.press(tcell.KeyEscape, 'q'); !strings.Contains(text, "Press ` to see help") {
+		t.Errorf("q is not properly replaced by the remapped quit key!\nOutput: %v\n", text)
+	}
+	if text := p.press('/'); !strings.Contains(text, "search for: ") {
+		t.Errorf("/ is not properly showing the search!\nOutput: %v\n", text)
+	}
+	// Leaving the search goes to the preset colors
+	if text := p.press(tcell.KeyEscape, 'C'); strings.Contains(text, "Css Pages") {
+		t.Errorf("C is not properly going to the next page!\nOutput: %v\n", text)
+	}
+
+	if _, err := p.finish(tcell.KeyCtrlQ); !errors.Is(err, cpick.ErrCanceled) {
+		t.Errorf("Ctrl-Q is not properly quitting!\nOutput: %v\n", err)
+	}
+}
+
+func Test_StartToggleGrayscale(t *testing.T) {
+	isolateFiles(t)
+	p := startTestPicker(t, cpick.Config{NoRestore: true}, 0, 0)
+
+	// Test that grayscale mode focuses the saturation-value table, and that
+	// the hue screen can't be switched to
+	p.press('w', tcell.KeyTab, 'j')
+	c, err := p.finish(tcell.KeyEnter)
+	if err != nil || c.RGB.R != c.RGB.G || c.RGB.G != c.RGB.B || c.HSV.V != 98 {
+		t.Errorf("w is not properly turning on grayscale mode!\nOutput: %v, %v\n", c, err)
+	}
+}
+
+func Test_StartHexInput(t *testing.T) {
+	isolateFiles(t)
+	p := startTestPicker(t, cpick.Config{NoRestore: true}, 220, 60)
+
+	// Test that the color values are shown while typing, and that typing
+	// doesn't run commands
+	if text := p.press('#', "ff8800"); !strings.Contains(text, "#ff8800") || !strings.Contains(text, "HSV: 32°, 100%, 100%") {
+		t.Errorf("The hex input is not properly showing the typed color!\nOutput: %v\n", text)
+	}
+
+	// Test that an invalid hex value is not accepted
+	p.press(tcell.KeyBackspace2, tcell.KeyBackspace2)
+	if text := p.press(tcell.KeyEnter); strings.Contains(text, "H: 32 S:100 V:100") {
+		t.Errorf("The hex input is accepting an invalid hex value!\nOutput: %v\n", text)
+	}
+
+	// Test leaving the hex input
+	if _, err := p.finish(tcell.KeyEscape, 'q'); !errors.Is(err, cpick.ErrCanceled) {
+		t.Errorf("Escape is not properly leaving the hex input!\nOutput: %v\n", err)
+	}
+}
+
+func Test_StartHueInput(t *testing.T) {
+	isolateFiles(t)
+	p := startTestPicker(t, cpick.Config{NoRestore: true}, 220, 60)
+
+	// Test that the color values are shown while typing
+	if text := p.press(':', "360"); !strings.Contains(text, "360") {
+		t.Errorf("The hue input is not properly showing the typed hue!\nOutput: %v\n", text)
+	}
+
+	// Test that an invalid hue is not accepted
+	p.press(tcell.KeyEnter, tcell.KeyBackspace2, tcell.KeyBackspace2, tcell.KeyBackspace2)
+	if text := p.press("200"); !strings.Contains(text, "HSV: 200°") {
+		t.Errorf("The hue input is not properly showing the typed hue!\nOutput: %v\n", text)
+	}
+
+	// Test selecting the hue
+	c, err := p.finish(tcell.KeyEnter, tcell.KeyEnter, tcell.KeyEnter)
+	if err != nil || c.HSV.H != 200 {
+		t.Errorf("The hue input is not properly selecting the hue!\nOutput: %v, %v\n", c, err)
+	}
+}
+
+func Test_StartWide(t *testing.T) {
+	isolateFiles(t)
+
+	// A wide screen has a hue for every second degree
+	p := startTestPicker(t, cpick.Config{NoRestore: true}, 220, 60)
+	if text := p.press('l'); !strings.Contains(text, "HSV: 2°, 100%, 100%") {
+		t.Errorf("The hue table is not properly scaled on a wide screen!\nOutput: %v\n", text)
+	}
+	if line := p.line(0); strings.Count(line, "▐") != 180 {
+		t.Errorf("The hue table is not properly drawn on a wide screen!\nOutput: %v\n", line)
+	}
+}
+
+func Test_StartTint(t *testing.T) {
+	isolateFiles(t)
+
+	// Select the first tint of red, next to the first shade
+	p := startTestPicker(t, cpick.Config{NoRestore: true}, 220, 60)
+	p.press(tcell.KeyTab, 'T', 'g', 'l')
+
+	c, err := p.finish(tcell.KeyEnter)
+	if err != nil || c.HSV.S != 90 || c.HSV.V != 100 {
+		t.Errorf("StartWithConfig is not properly selecting a tint!\nOutput: %v, %v\n", c, err)
+	}
+}
+
+func Test_StartUndo(t *testing.T) {
+	isolateFiles(t)
+	p := startTestPicker(t, cpick.Config{NoRestore: true}, 0, 0)
+
+	// Test undoing and redoing selections of the saturation-value table
+	p.press(tcell.KeyTab, 'j', 'j')
+	if text := p.press('u'); !strings.Contains(text, "H:  0 S:100 V: 98") {
+		t.Errorf("u is not properly undoing the selection!\nOutput: %v\n", text)
+	}
+	if text := p.press('u'); !strings.Contains(text, "H:  0 S:100 V:100") {
+		t.Errorf("u is not properly undoing the selection!\nOutput: %v\n", text)
+	}
+	if text := p.press(tcell.KeyCtrlR, tcell.KeyCtrlR); !strings.Contains(text, "H:  0 S:100 V: 96") {
+		t.Errorf("Ctrl-R is not properly redoing the selection!\nOutput: %v\n", text)
+	}
+
+	// Test undoing a change of hue
+	p.press(tcell.KeyTab, 'l', tcell.KeyEnter)
+	if text := p.press('u'); !strings.Contains(text, "H:  0 S:100 V: 96") {
+		t.Errorf("u is not properly undoing the change of hue!\nOutput: %v\n", text)
+	}
+
+	// Test that a new selection clears the redo stack
+	p.press('h')
+	before := p.press()
+	if text := p.press(tcell.KeyCtrlR); text != before {
+		t.Errorf("A new selection is not properly clearing the redo stack!\nOutput: %v\n", text)
+	}
+}
+
+func Test_StartNudge(t *testing.T) {
+	isolateFiles(t)
+	p := startTestPicker(t, cpick.Config{NoRestore: true}, 220, 60)
+
+	// Test changing the value and saturation, which change by 5 on a screen
+	// of this size
+	if text := p.press(tcell.KeyTab, '-', '-'); !strings.Contains(text, "H:  0 S:100 V: 90") || !strings.Contains(text, "#e60000") {
+		t.Errorf("- is not properly lowering the value!\nOutput: %v\n", text)
+	}
+	if text := p.press('['); !strings.Contains(text, "H:  0 S: 95 V: 90") {
+		t.Errorf("[ is not properly lowering the saturation!\nOutput: %v\n", text)
+	}
+
+	// Test that the values are clamped
+	if text := p.press(']', ']', '+', '+', '+'); !strings.Contains(text, "H:  0 S:100 V:100") {
+		t.Errorf("] and + are not properly clamping the values!\nOutput: %v\n", text)
+	}
+}
+
+func Test_StartLargePreview(t *testing.T) {
+	isolateFiles(t)
+	p := startTestPicker(t, cpick.Config{NoRestore: true}, 0, 0)
+
+	before := p.text()
+	if text := p.press('L'); strings.Count(text, "█") <= strings.Count(before, "█") {
+		t.Errorf("L is not properly showing the large color blocks!\nOutput: %v\n", text)
+	}
+	if text := p.press('L'); text != before {
+		t.Errorf("L is not properly showing the normal color blocks!\nOutput: %v\n", text)
+	}
+}
+
+func Test_StartReload(t *testing.T) {
+	isolateFiles(t)
+	path := writeConfigFile(t, "colors.json", `{"colorList": [{"name": "first", "colors": [{"name": "myblue", "value": "#3366ff"}]}, {"name": "second", "colors": [{"name": "myred", "value": "#ff0000"}]}]}`)
+
+	// Test that reloading keeps the shown page
+	p := startTestPicker(t, cpick.Config{NoRestore: true}, 0, 0)
+	p.press(' ', 'C')
+	if text := p.press(tcell.KeyF5); !strings.Contains(text, "Second") || !strings.Contains(text, "myred") {
+		t.Errorf("F5 is not properly keeping the page!\nOutput: %v\n", text)
+	}
+
+	// Test that an invalid file shows an error
+	if err := os.WriteFile(path, []byte(`{"colorList": [`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if text := p.press(tcell.KeyF5); !strings.Contains(text, "Could not reload the colors") {
+		t.Errorf("F5 is not properly showing an error for an invalid file!\nOutput: %v\n", text)
+	}
+
+	// Test hiding the error, which keeps the colors that were shown
+	if text := p.press(tcell.KeyEnter); strings.Contains(text, "Could not reload the colors") || !strings.Contains(text, "myred") {
+		t.Errorf("Enter is not properly hiding the error!\nOutput: %v\n", text)
+	}
+}
+
+func Test_StartNearest(t *testing.T) {
+	isolateFiles(t)
+	p := startTestPicker(t, cpick.Config{NoRestore: true}, 220, 60)
+
+	// Test showing the nearest preset color
+	if text := p.press('?', "#b32424", tcell.KeyEnter); !strings.Contains(text, "≈ Firebrick (#b22222)") {
+		t.Errorf("The nearest preset color is not properly shown!\nOutput: %v\n", text)
+	}
+
+	// Test that searching for a hex value highlights the nearest preset
+	// colors, and that they can be cycled through
+	if text := p.press(tcell.KeyTab, ' '); !strings.Contains(text, "HSV: 0°, 81%, 70%") {
+		t.Errorf("The search is not properly highlighting the nearest preset color!\nOutput: %v\n", text)
+	}
+	if text := p.press('N'); !strings.Contains(text, "HSV: 0°, 75%, 65%") {
+		t.Errorf("N is not properly going to the next nearest preset color!\nOutput: %v\n", text)
+	}
+
+	c, err := p.finish(tcell.KeyEnter, tcell.KeyEnter)
+	if err != nil || c.Name != "brown" {
+		t.Errorf("Enter is not properly selecting the nearest preset color!\nOutput: %v, %v\n", c, err)
+	}
+
+	// Test generating a name from the nearest preset color
+	cpick.GenerateNames = true
+	defer func() { cpick.GenerateNames = false }()
+
+	if c := startWithKeys(t, cpick.Config{NoRestore: true}, '?', "#b32424", tcell.KeyEnter, tcell.KeyEnter); c.Name != "firebrick-b32424" {
+		t.Errorf("StartWithConfig is not properly generating a name!\nOutput: %v\n", c)
+	}
+}
+
+func Test_StartSort(t *testing.T) {
+	isolateFiles(t)
+	p := startTestPicker(t, cpick.Config{NoRestore: true}, 0, 0)
+
+	// Test sorting by hue, and then by name
+	if text := p.press(' ', 'o'); !strings.Contains(text, "(sorted by hue)") {
+		t.Errorf("o is not properly sorting the colors by hue!\nOutput: %v\n", text)
+	}
+	if text := p.press('o', 'o'); !strings.Contains(text, "(sorted by name)") || !strings.Contains(text, "aliceblue") {
+		t.Errorf("o is not properly sorting the colors by name!\nOutput: %v\n", text)
+	}
+
+	// Test going back to the default order
+	if text := p.press('o'); strings.Contains(text, "(sorted by") {
+		t.Errorf("o is not properly going back to the default order!\nOutput: %v\n", text)
+	}
+}
+
+func Test_StartFilter(t *testing.T) {
+	isolateFiles(t)
+	p := startTestPicker(t, cpick.Config{NoRestore: true}, 0, 0)
+
+	// Test that only matching colors are shown
+	text := p.press(' ', '/', "Blue")
+	if !strings.Contains(text, "aliceblue") || !strings.Contains(text, "cadetblue") || strings.Contains(text, "antiquewhite") {
+		t.Errorf("The filter is not properly filtering the colors!\nOutput: %v\n", text)
+	}
+
+	// Test that a filter with no matches shows no colors
+	p.press(tcell.KeyCtrlU)
+	if text := p.press("not a color"); strings.Contains(text, "aliceblue") {
+		t.Errorf("The filter is not properly hiding every color!\nOutput: %v\n", text)
+	}
+
+	// Test that clearing the filter shows every color again
+	if text := p.press(tcell.KeyEscape); !strings.Contains(text, "antiquewhite") {
+		t.Errorf("Escape is not properly clearing the filter!\nOutput: %v\n", text)
+	}
+
+	c, err := p.finish(tcell.KeyEnter, tcell.KeyEnter)
+	if err != nil || c.Name != "aliceblue" {
+		t.Errorf("The preset colors are not properly selected after the filter!\nOutput: %v, %v\n", c, err)
+	}
+}
+
+func Test_StartFuzzySearch(t *testing.T) {
+	isolateFiles(t)
+	p := startTestPicker(t, cpick.Config{NoRestore: true}, 0, 0)
+
+	// Test that a name with a typo is suggested
+	if text := p.press('?', "ligthblue"); !strings.Contains(text, "lightblue") {
+		t.Errorf("The search is not properly suggesting a name with a typo!\nOutput: %v\n", text)
+	}
+
+	// The first enter takes the suggested name, the second one closes the
+	// suggestions of the names that start with it, and the third one searches
+	c, err := p.finish(tcell.KeyEnter, tcell.KeyEnter, tcell.KeyEnter, tcell.KeyEnter, tcell.KeyEnter)
+	if err != nil || c.Name != "lightblue" {
+		t.Errorf("The search is not properly finding a name with a typo!\nOutput: %v, %v\n", c, err)
+	}
+}
+
+func Test_Start256Palette(t *testing.T) {
+	isolateFiles(t)
+	p := startTestPicker(t, cpick.Config{NoRestore: true}, 0, 0)
+
+	// Test showing an orange of the palette, which has 16 colors in a row
+	p.press('X')
+	for i := 0; i < 13; i++ {
+		p.press('j')
+	}
+	if text := p.text(); !strings.Contains(text, "256 color palette") || !strings.Contains(text, "Index: 208") || !strings.Contains(text, "Hex: #ff8700") {
+		t.Errorf("The 256 color palette is not properly showing the color!\nOutput: %v\n", text)
+	}
+
+	c, err := p.finish(tcell.KeyEnter)
+	if err != nil || c.ColorIndex != 208 || c.Ansi != "\x1b[38;5;208m" || c.Hex != "ff8700" {
+		t.Errorf("The 256 color palette is not properly selecting the color!\nOutput: %v, %v\n", c, err)
+	}
+}
+
+func Test_Start16Palette(t *testing.T) {
+	isolateFiles(t)
+	p := startTestPicker(t, cpick.Config{NoRestore: true}, 220, 60)
+
+	// Test showing red, which is next to black
+	if text := p.press('x', 'g', 'l'); !strings.Contains(text, "16 standard terminal colors") || !strings.Contains(text, "SGR code: 31") {
+		t.Errorf("The 16 standard colors are not properly showing the color!\nOutput: %v\n", text)
+	}
+
+	// Test going back
+	if text := p.press(tcell.KeyEscape); !strings.Contains(text, "Press ` to see help") {
+		t.Errorf("Escape is not properly hiding the 16 standard colors!\nOutput: %v\n", text)
+	}
+
+	// Test selecting bright red, which is below red
+	c, err := p.finish('x', 'g', 'l', 'j', tcell.KeyEnter)
+	if err != nil || c.ColorIndex != 9 || c.Ansi != "\x1b[91m" || c.Hex != "ff0000" || c.Name != "bright red" {
+		t.Errorf("The 16 standard colors are not properly selecting the color!\nOutput: %v, %v\n", c, err)
+	}
+}
+
+func Test_StartSliders(t *testing.T) {
+	isolateFiles(t)
+	p := startTestPicker(t, cpick.Config{NoRestore: true}, 220, 60)
+
+	// Test that the sliders start at the highlighted color
+	p.press('?', "hsv: 120 40 80", tcell.KeyEnter)
+	if text := p.press('S'); !strings.Contains(text, "Adjust the hue, saturation, and value of the color") || !strings.Contains(text, "HSV: 120°, 40%, 80%") {
+		t.Errorf("S is not properly showing the sliders at the highlighted color!\nOutput: %v\n", text)
+	}
+
+	// Test changing the hue
+	if text := p.press('l'); !strings.Contains(text, "HSV: 121°, 40%, 80%") {
+		t.Errorf("The sliders are not properly changing the color!\nOutput: %v\n", text)
+	}
+
+	// Test going back
+	if text := p.press('S'); !strings.Contains(text, "H:120 S: 40 V: 80") {
+		t.Errorf("S is not properly hiding the sliders!\nOutput: %v\n", text)
+	}
+
+	c, err := p.finish('S', 'l', tcell.KeyEnter)
+	if err != nil || c.HSV != (color.HSV{H: 121, S: 40, V: 80}) {
+		t.Errorf("The sliders are not properly selecting the color!\nOutput: %v, %v\n", c, err)
+	}
+}
+
+func Test_StartGradient(t *testing.T) {
+	isolateFiles(t)
+	p := startTestPicker(t, cpick.Config{NoRestore: true}, 220, 60)
+
+	// Test picking the start and the end of the gradient
+	if text := p.press(tcell.KeyTab, 'e'); !strings.Contains(text, "Gradient from #ff0000") {
+		t.Errorf("e is not properly setting the start of the gradient!\nOutput: %v\n", text)
+	}
+	p.press('?', "#000000", tcell.KeyEnter)
+	if text := p.press('e'); !strings.Contains(text, fmt.Sprintf("Gradient of %v colors from #ff0000 to #000000", cpick.GRADIENT_STEPS)) {
+		t.Errorf("e is not properly showing the gradient!\nOutput: %v\n", text)
+	}
+
+	// Test removing a color, which keeps the last color selected
+	if text := p.press('G', '-'); !strings.Contains(text, fmt.Sprintf("Gradient of %v colors", cpick.GRADIENT_STEPS-1)) {
+		t.Errorf("- is not properly removing a color!\nOutput: %v\n", text)
+	}
+
+	// Test going back
+	if text := p.press('+', tcell.KeyEscape); !strings.Contains(text, "H:  0 S:  0 V:  0") {
+		t.Errorf("Escape is not properly hiding the gradient!\nOutput: %v\n", text)
+	}
+
+	c, err := p.finish('e', 'e', 'G', '-', tcell.KeyEnter)
+	if err != nil || c.Hex != "000000" {
+		t.Errorf("Enter is not properly selecting a color of the gradient!\nOutput: %v, %v\n", c, err)
+	}
+}
+
+func Test_StartCMYKInk(t *testing.T) {
+	isolateFiles(t)
+	p := startTestPicker(t, cpick.Config{NoRestore: true}, 220, 60)
+
+	// Test showing the total amount of ink of red
+	if text := p.press(tcell.KeyTab, 'K'); !strings.Contains(text, "Total ink: 200%") {
+		t.Errorf("K is not properly showing the total amount of ink!\nOutput: %v\n", text)
+	}
+
+	// Test warning about too much ink, by adding all of the cyan and some
+	// black
+	if text := p.press(tcell.KeyEnd, tcell.KeyTab, tcell.KeyTab, tcell.KeyTab, tcell.KeyRight); !strings.Contains(text, "over 300%") {
+		t.Errorf("The CMYK page is not properly warning about too much ink!\nOutput: %v\n", text)
+	}
+
+	// Test going back
+	if text := p.press('K'); !strings.Contains(text, "H:  0 S:100 V:100") {
+		t.Errorf("K is not properly hiding the CMYK page!\nOutput: %v\n", text)
+	}
+}
+
+func Test_StartSVRows(t *testing.T) {
+	isolateFiles(t)
+
+	// With 100 rows, every value has its own half of a cell
+	p := startTestPicker(t, cpick.Config{NoRestore: true, SVRows: 100, Step: 1}, 220, 110)
+	p.press('?', "hsv: 0 50 37", tcell.KeyEnter)
+	if text := p.press('+'); !strings.Contains(text, "H:  0 S: 50 V: 38") {
+		t.Errorf("+ is not properly changing the value by one!\nOutput: %v\n", text)
+	}
+
+	// The number of rows is kept within the screen
+	p.finish('q')
+	p = startTestPicker(t, cpick.Config{NoRestore: true, SVRows: 100}, 220, 40)
+	c, err := p.finish(tcell.KeyTab, 'G', tcell.KeyEnter)
+	if err != nil || c.HSV.V != 0 {
+		t.Errorf("The saturation-value table is not properly fitting the screen!\nOutput: %v, %v\n", c, err)
+	}
+}
+
+func Test_StartPageJump(t *testing.T) {
+	isolateFiles(t)
+	p := startTestPicker(t, cpick.Config{NoRestore: true}, 0, 0)
+
+	// Test jumping to the last and first pages
+	p.press(' ')
+	if text := p.press('>'); strings.Contains(text, "Css Pages") {
+		t.Errorf("> is not properly going to the last page!\nOutput: %v\n", text)
+	}
+	if text := p.press('<'); !strings.Contains(text, "Css Pages") {
+		t.Errorf("< is not properly going to the first page!\nOutput: %v\n", text)
+	}
+
+	// Test moving several pages with a count, which is the same as moving
+	// one page at a time
+	count := p.press('2', 'C')
+	if text := p.press('<', 'C', 'C'); text != count {
+		t.Errorf("A count is not properly moving several pages!\nOutput: %v\n", count)
+	}
+
+	// Test that a count past the first page stops on it
+	if text := p.press('5', '0', 'c'); !strings.Contains(text, "Css Pages") {
+		t.Errorf("A count is not properly stopping on the first page!\nOutput: %v\n", text)
+	}
+}
+
+func Test_StartFavorites(t *testing.T) {
+	isolateFiles(t)
+	p := startTestPicker(t, cpick.Config{NoRestore: true}, 0, 0)
+
+	// Test adding the highlighted color once
+	p.press(tcell.KeyTab, 'b', 'b')
+	path := filepath.Join(os.Getenv("HOME"), ".config", "cpick", "favorites.json")
+	var favorites []string
+	if raw, err := os.ReadFile(path); err != nil || json.Unmarshal(raw, &favorites) != nil || strings.Join(favorites, " ") != "ff0000" {
+		t.Errorf("b is not properly adding the color once!\nOutput: %v, %v\n", favorites, err)
+	}
+
+	// Test showing the favorites
+	if text := p.press('B'); !strings.Contains(text, "Favorite colors") || !strings.Contains(text, "#ff0000") {
+		t.Errorf("B is not properly showing the favorites!\nOutput: %v\n", text)
+	}
+
+	// Test removing the highlighted favorite
+	if text := p.press('d'); strings.Contains(text, "#ff0000") {
+		t.Errorf("d is not properly removing the favorite!\nOutput: %v\n", text)
+	}
+
+	// Test going back
+	if text := p.press('B'); !strings.Contains(text, "H:  0 S:100 V:100") {
+		t.Errorf("B is not properly hiding the favorites!\nOutput: %v\n", text)
+	}
+
+	// Test selecting a favorite
+	c, err := p.finish('j', 'b', 'B', tcell.KeyEnter)
+	if err != nil || c.Hex != "fa0000" {
+		t.Errorf("Enter is not properly selecting a favorite!\nOutput: %v, %v\n", c, err)
+	}
+}
+
+func Test_StartMouse(t *testing.T) {
+	isolateFiles(t)
+	p := startTestPicker(t, cpick.Config{NoRestore: true}, 220, 60)
+
+	// Test clicking on a color of the saturation-value table
+	p.press(tcell.KeyTab)
+	if text := p.click(20, 10); !strings.Contains(text, "H:  0 S: 21 V: 80") {
+		t.Errorf("A click is not properly selecting the clicked color!\nOutput: %v\n", text)
+	}
 
+	// Test dragging to another color
+	if text := p.mouse(tcell.NewEventMouse(30, 12, tcell.Button1, tcell.ModNone)); !strings.Contains(text, "H:  0 S: 31 V: 76") {
+		t.Errorf("A drag is not properly following the mouse!\nOutput: %v\n", text)
+	}
+
+	// Test that moving without a button pressed is ignored
+	release := tcell.NewEventMouse(30, 12, tcell.ButtonNone, tcell.ModNone)
+	if text := p.mouse(release, tcell.NewEventMouse(0, 0, tcell.ButtonNone, tcell.ModNone)); !strings.Contains(text, "H:  0 S: 31 V: 76") {
+		t.Errorf("The mouse is followed without a button pressed!\nOutput: %v\n", text)
+	}
+
+	// Test clicking on a preset color, which shows it on the
+	// saturation-value table
+	p.press(tcell.KeyTab)
+	x, y, _ := p.find("aliceblue")
+	if text := p.click(x, y); !strings.Contains(text, "H:208 S:  6 V:100") {
+		t.Errorf("A click is not properly selecting the clicked preset color!\nOutput: %v\n", text)
+	}
+
+	c, err := p.finish(tcell.KeyEnter)
+	if err != nil || c.Hex != "f0f8ff" || c.Name != "aliceblue" {
+		t.Errorf("The clicked preset color is not properly selected!\nOutput: %v, %v\n", c, err)
+	}
+}
+
+func Test_StartSingleTint(t *testing.T) {
+	isolateFiles(t)
+	p := startTestPicker(t, cpick.Config{NoRestore: true, SingleTint: true}, 220, 60)
+
+	// Test showing only the color that is selected
+	text := p.press(tcell.KeyTab, 'j')
+	if strings.Contains(text, "Light Tint Color") || strings.Count(text, "Hex: #") != 1 || !strings.Contains(text, "Hex: #fa0000") {
+		t.Errorf("SingleTint is not properly showing only the selected color!\nOutput: %v\n", text)
+	}
+
+	c, err := p.finish(tcell.KeyEnter)
+	if err != nil || c.Hex != "fa0000" {
+		t.Errorf("SingleTint is not properly selecting the shown color!\nOutput: %v, %v\n", c, err)
+	}
+}
+
+func Test_StartSVHalf(t *testing.T) {
+	isolateFiles(t)
+	p := startTestPicker(t, cpick.Config{NoRestore: true, Step: 1}, 220, 60)
+
+	// Test switching to the bottom half of the selected cell
+	if text := p.press(tcell.KeyTab, 'j', 't'); !strings.Contains(text, "H:  0 S:100 V: 97") {
+		t.Errorf("t is not properly selecting the bottom half!\nOutput: %v\n", text)
+	}
+
+	// Test nudging the value into the top half
+	if text := p.press('+'); !strings.Contains(text, "H:  0 S:100 V: 98") {
+		t.Errorf("+ is not properly moving to the top half!\nOutput: %v\n", text)
+	}
+
+	// Test selecting the bottom half
+	c, err := p.finish('t', tcell.KeyEnter)
+	if err != nil || c.HSV != (color.HSV{H: 0, S: 100, V: 97}) {
+		t.Errorf("Enter is not properly selecting the bottom half!\nOutput: %v, %v\n", c, err)
+	}
+}
+
+func Test_StartTheme(t *testing.T) {
+	var inputs = map[string]int32{"light": 0xffffff, "": 0x000000, "unknown": 0x000000}
+	for theme, background := range inputs {
+		isolateFiles(t)
+		p := startTestPicker(t, cpick.Config{NoRestore: true, Theme: theme}, 220, 60)
+
+		x, y, _ := p.find("Press ` to see help")
+		if _, bg, _ := p.style(x, y).Decompose(); bg.Hex() != background {
+			t.Errorf("The %q theme is not properly drawing the background!\nOutput: %06x\n", theme, bg.Hex())
+		}
+
+		p.finish(tcell.KeyCtrlC)
+	}
+}
+
+func Test_StartEmphasis(t *testing.T) {
+	isolateFiles(t)
+	p := startTestPicker(t, cpick.Config{NoRestore: true, Emphasis: "hex"}, 220, 60)
+
+	// Test moving the hex value above the other values in bold
+	x, y, _ := p.find("Hex: #ff0000")
+	if _, rgbY, _ := p.find("RGB: 255, 0, 0"); y > rgbY {
+		t.Errorf("Emphasis is not properly moving the hex value to the top!\nOutput: %v\n", p.text())
+	}
+	if _, _, attrs := p.style(x, y).Decompose(); attrs&tcell.AttrBold == 0 {
+		t.Errorf("Emphasis is not properly showing the hex value in bold!\nOutput: %v\n", attrs)
+	}
+
+	// Test cycling to the next type, which is remembered
+	p.press('f')
+	if _, decimalY, _ := p.find("Decimal: 16711680"); decimalY > y {
+		t.Errorf("f is not properly cycling the emphasized type!\nOutput: %v\n", p.text())
+	}
+	if raw, err := os.ReadFile(filepath.Join(os.Getenv("HOME"), ".config", "cpick", "config.json")); err != nil || !strings.Contains(string(raw), `"emphasis": "decimal"`) {
+		t.Errorf("f is not properly saving the emphasized type!\nOutput: %s, %v\n", raw, err)
+	}
+}
+
+func Test_StartSave(t *testing.T) {
+	isolateFiles(t)
+	p := startTestPicker(t, cpick.Config{NoRestore: true}, 220, 60)
+
+	// Test that a name is required
+	if text := p.press('s', tcell.KeyEnter); !strings.Contains(text, `to the "custom" colors of colors.json`) {
+		t.Errorf("Enter is not properly requiring a name!\nOutput: %v\n", text)
+	}
+
+	// Test going back
+	if text := p.press(tcell.KeyEscape); strings.Contains(text, `to the "custom" colors of colors.json`) {
+		t.Errorf("Escape is not properly going back!\nOutput: %v\n", text)
+	}
+
+	// Test creating a color file with the preset colors and the saved color.
+	// The message is shown as it is typed instead of as color tags.
+	path := filepath.Join(os.Getenv("HOME"), ".config", "cpick", "colors.json")
+	p.press('s', "mine", tcell.KeyEnter)
+	if text := p.press('s', "[red]", tcell.KeyEnter); !strings.Contains(text, "Saved [red] (#ff0000) to "+path) {
+		t.Errorf("s is not properly showing the saved color!\nOutput: %v\n", text)
+	}
+
+	var data struct {
+		ColorList []struct {
+			Name   string `json:"name"`
+			Colors []struct {
+				Name  string `json:"name"`
+				Value string `json:"value"`
+			} `json:"colors"`
+		} `json:"colorList"`
+	}
+	raw, err := os.ReadFile(path)
+	if err != nil || json.Unmarshal(raw, &data) != nil || len(data.ColorList) < 2 {
+		t.Fatalf("s is not properly writing the colors!\nOutput: %s, %v\n", raw, err)
+	}
+	last := data.ColorList[len(data.ColorList)-1]
+	if data.ColorList[0].Name != "css" || last.Name != "custom" || len(last.Colors) != 2 || last.Colors[0].Name != "mine" || last.Colors[0].Value != "#ff0000" {
+		t.Errorf("s is not properly saving the colors!\nOutput: %v\n", last)
+	}
+
+	// Test showing an error in red when the file can't be written
+	if os.Getuid() == 0 {
+		return
+	}
+	if err := os.Chmod(path, 0444); err != nil {
+		t.Fatal(err)
+	}
+	p.press('s', "theirs", tcell.KeyEnter)
+	x, y, ok := p.find(path)
+	if fg, _, _ := p.style(x, y).Decompose(); !ok || fg != tcell.ColorRed {
+		t.Errorf("s is not properly showing an error for a read only file!\nOutput: %v\n", p.text())
+	}
+}
+
+func Test_StartNamedList(t *testing.T) {
+	isolateFiles(t)
+	p := startTestPicker(t, cpick.Config{NoRestore: true}, 220, 60)
+
+	// Test showing the named colors in alphabetical order
+	p.press('I')
+	_, titleY, ok := p.find("Named preset colors")
+	_, aliceY, _ := p.find("aliceblue")
+	_, antiqueY, _ := p.find("antiquewhite")
+	if !ok || aliceY < titleY || antiqueY < aliceY || !strings.Contains(p.line(aliceY+1), "#f0f8ff") {
+		t.Errorf("I is not properly showing the named colors!\nOutput: %v\n", p.text())
+	}
+
+	// Test going back
+	if text := p.press('I'); strings.Contains(text, "Named preset colors") {
+		t.Errorf("I is not properly hiding the named colors!\nOutput: %v\n", text)
+	}
+
+	c, err := p.finish('I', tcell.KeyEnter)
+	if err != nil || c.Name != "aliceblue" {
+		t.Errorf("Enter is not properly selecting the named color!\nOutput: %v, %v\n", c, err)
+	}
+}
+
+func Test_StartHexShorthand(t *testing.T) {
+	isolateFiles(t)
+	p := startTestPicker(t, cpick.Config{NoRestore: true}, 220, 60)
+
+	// Test showing the expanded hex value in the autocomplete, which the
+	// first enter takes
+	p.press('?')
+	if text := p.press("f80"); !strings.Contains(text, "#ff8800") {
+		t.Errorf("The autocomplete is not properly showing the expanded hex value!\nOutput: %v\n", text)
+	}
+	if text := p.press(tcell.KeyEnter, tcell.KeyEnter); !strings.Contains(text, "H: 32 S:100 V:100") || !strings.Contains(text, "Hex: #ff8800") {
+		t.Errorf("The search is not properly selecting a hex value without the \"#\"!\nOutput: %v\n", text)
+	}
+
+	// Test searching for a hex value with an alpha channel, and that an alpha
+	// channel of ff makes the color opaque again
+	if text := p.press(tcell.KeyTab, '?', "#ff8800cc", tcell.KeyEnter); !strings.Contains(text, "H: 32 S:100 V:100") || !strings.Contains(text, "Alpha: 0.8") {
+		t.Errorf("The search is not properly selecting a hex value with an alpha channel!\nOutput: %v\n", text)
+	}
+	if text := p.press(tcell.KeyTab, '?', "#ff8800ff", tcell.KeyEnter); !strings.Contains(text, "Alpha: 1") {
+		t.Errorf("The search is not properly resetting the alpha with an alpha channel of ff!\nOutput: %v\n", text)
+	}
+
+	// Test that hex text which is also part of a name searches for the name
+	p.press(tcell.KeyTab, '?')
+	if text := p.press("cad"); !strings.Contains(text, "cadetblue") || strings.Contains(text, "#ccaadd") {
+		t.Errorf("The autocomplete is not properly leaving out a hex value that is also part of a name!\nOutput: %v\n", text)
+	}
+
+	c, err := p.finish(tcell.KeyEnter, tcell.KeyEnter, tcell.KeyEnter, tcell.KeyEnter, tcell.KeyEnter)
+	if err != nil || c.Name != "cadetblue" {
+		t.Errorf("The search is not properly searching for a name that looks like a hex value!\nOutput: %v, %v\n", c, err)
+	}
+}
+
+func Test_StartRandom(t *testing.T) {
+	isolateFiles(t)
+	p := startTestPicker(t, cpick.Config{NoRestore: true}, 220, 60)
+
+	// Test jumping to a random color on the saturation-value table
+	if text := p.press('r'); !strings.Contains(text, "H:") || !strings.Contains(text, " S:") {
+		t.Errorf("r is not properly selecting a random color!\nOutput: %v\n", text)
+	}
+
+	// Test that the random colors are in range and not all the same
+	seen := make(map[color.HSV]bool)
+	for i := 0; i < 10; i++ {
+		c := cpick.Random()
+		if c.Hex != color.HSVtoHex(c.HSV) || c.HSV.H < 0 || c.HSV.H > 359 || c.HSV.S < 0 || c.HSV.S > 100 || c.HSV.V < 0 || c.HSV.V > 100 {
+			t.Errorf("Random is not properly creating the color values!\nOutput: %v\n", c)
+		}
+		seen[c.HSV] = true
+	}
+	if len(seen) < 2 {
+		t.Errorf("Random is not properly creating different colors!\n")
+	}
+}
+
+func Test_StartColorMode(t *testing.T) {
+	isolateFiles(t)
+	p := startTestPicker(t, cpick.Config{NoRestore: true}, 220, 60)
+
+	// Test that colors are drawn in truecolor by default
+	if fg, _, _ := p.style(1, 0).Decompose(); fg&tcell.ColorIsRGB == 0 {
+		t.Errorf("The colors are not properly drawn in truecolor!\nOutput: %v\n", fg)
+	}
+
+	// Test drawing the colors with the 256 color palette
+	text := p.press('M')
+	if fg, _, _ := p.style(1, 0).Decompose(); fg&tcell.ColorIsRGB != 0 || !strings.Contains(text, "Drawing colors with the nearest of the 256 terminal colors") {
+		t.Errorf("M is not properly drawing the nearest of the 256 colors!\nOutput: %v\n", fg)
+	}
+
+	// Test going back to truecolor
+	p.press('M')
+	if fg, _, _ := p.style(1, 0).Decompose(); fg&tcell.ColorIsRGB == 0 {
+		t.Errorf("M is not properly going back to truecolor!\nOutput: %v\n", fg)
+	}
+}
+
+func Test_StartTruecolorWarning(t *testing.T) {
+	// A simulation screen only has 256 colors, so truecolor only comes from
+	// COLORTERM
+	t.Setenv("COLORTERM", "")
+
+	// Test suggesting the 256 color mode on a limited terminal
+	isolateFiles(t)
+	p := startTestPicker(t, cpick.Config{NoRestore: true}, 220, 60)
+	if text := p.text(); !strings.Contains(text, "-color-mode 256") {
+		t.Errorf("The 256 color mode is not properly suggested!\nOutput: %v\n", text)
+	}
+	p.finish(tcell.KeyCtrlC)
+
+	// Test that there is no warning in the 256 color mode
+	p = startTestPicker(t, cpick.Config{NoRestore: true, ColorMode: "256"}, 220, 60)
+	if text := p.text(); strings.Contains(text, "-color-mode 256") {
+		t.Errorf("The 256 color mode is suggested while it is used!\nOutput: %v\n", text)
+	}
+}
+
+func Test_StartHiddenPresets(t *testing.T) {
+	isolateFiles(t)
+	p := startTestPicker(t, cpick.Config{NoRestore: true, NoPresets: true}, 220, 60)
+
+	// Test that colors can't be searched by name without the presets
+	if text := p.press('?', "red", tcell.KeyEnter); !strings.Contains(text, "hidden") {
+		t.Errorf("The search is not properly handling hidden preset colors!\nOutput: %v\n", text)
+	}
+
+	// Test that the named list isn't shown
+	if text := p.press(tcell.KeyEscape, 'I'); strings.Contains(text, "Named preset colors") || !strings.Contains(text, "The preset colors are hidden") {
+		t.Errorf("I is showing the list without the preset colors!\nOutput: %v\n", text)
+	}
+}
+
+func Test_StartHiddenPresetNames(t *testing.T) {
+	isolateFiles(t)
+	path := writeConfigFile(t, "colors.json", `{"colorList": [{"name": "custom", "colors": [{"name": "mine", "value": "#ff0000"}]}]}`)
+	p := startTestPicker(t, cpick.Config{NoRestore: true, NoPresets: true}, 220, 60)
+
+	// Test that the colors of the color file still name the colors
+	if text := p.press(tcell.KeyTab); !strings.Contains(text, "≈ Mine") {
+		t.Errorf("The colors are not properly named without the presets!\nOutput: %v\n", text)
+	}
+
+	// Test that the color file is only read when the picker starts
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	if text := p.press('j'); !strings.Contains(text, "≈ Mine") {
+		t.Errorf("The color file is read again when the selection changes!\nOutput: %v\n", text)
+	}
+}
+
+func Test_StartLazyPages(t *testing.T) {
+	isolateFiles(t)
+	p := startTestPicker(t, cpick.Config{NoRestore: true}, 220, 60)
+
+	// Test that the colors of the last page can be searched before the page
+	// is shown
+	p.press('?', "navajowhite1", tcell.KeyEnter)
+	if text := p.press(tcell.KeyEnter); !strings.Contains(text, "navajowhite1    #ffd7af") {
+		t.Errorf("The search is not properly finding colors on pages that aren't shown!\nOutput: %v\n", text)
+	}
+
+	c, err := p.finish(tcell.KeyEnter, tcell.KeyEnter)
+	if err != nil || !strings.EqualFold(c.Name, "navajowhite1") {
+		t.Errorf("The found color on the last page is not properly selected!\nOutput: %v, %v\n", c, err)
+	}
+}
+
+func Test_StartColorCache(t *testing.T) {
+	isolateFiles(t)
+	writeConfigFile(t, "config.json", `{"cacheColors": true}`)
+	path := writeConfigFile(t, "colors.json", `{"colorList": [{"name": "custom", "colors": [{"name": "mine", "value": "#123456"}]}]}`)
+	info, err := os.Stat(path)
 	if err != nil {
-		fmt.Printf("cpick is not working in testing mode. If you think this is a bug, please report an issue on the gitlab page.\nError: %v", err)
-	} else {
-		fmt.Printf("# cpick is working in testing mode!\n")
+		t.Fatal(err)
+	}
+
+	// Show the named colors of the color file, which caches them
+	named := func() string {
+		p := startTestPicker(t, cpick.Config{NoRestore: true}, 220, 60)
+		text := p.press('I')
+		p.finish(tcell.KeyCtrlC)
+		return text
+	}
+	if text := named(); !strings.Contains(text, "mine") {
+		t.Fatalf("The colors of the color file are not properly shown!\nOutput: %v\n", text)
+	}
+
+	// Test that the cache is used while the file keeps its size and
+	// modification time
+	writeConfigFile(t, "colors.json", `{"colorList": [{"name": "custom", "colors": [{"name": "mind", "value": "#123456"}]}]}`)
+	if err := os.Chtimes(path, info.ModTime(), info.ModTime()); err != nil {
+		t.Fatal(err)
+	}
+	if text := named(); !strings.Contains(text, "mine") {
+		t.Errorf("The cached colors are not properly used!\nOutput: %v\n", text)
+	}
+
+	// Test that another color file doesn't replace the cache of the first
+	if err := os.WriteFile("colors.json", []byte(`{"colorList": [{"name": "custom", "colors": [{"name": "ours", "value": "#123456"}]}]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if text := named(); !strings.Contains(text, "ours") {
+		t.Errorf("The colors of another color file are not properly shown!\nOutput: %v\n", text)
+	}
+	if err := os.Remove("colors.json"); err != nil {
+		t.Fatal(err)
+	}
+	if text := named(); !strings.Contains(text, "mine") {
+		t.Errorf("The cached colors are not properly kept for each color file!\nOutput: %v\n", text)
+	}
+
+	// Test that the cache isn't used once the file changes
+	writeConfigFile(t, "colors.json", `{"colorList": [{"name": "custom", "colors": [{"name": "yours", "value": "#654321"}]}]}`)
+	if text := named(); !strings.Contains(text, "yours") {
+		t.Errorf("The cached colors of a changed file are used!\nOutput: %v\n", text)
+	}
+
+	// Test that the colors are still shown if the cache can't be written
+	writeConfigFile(t, "colors.json", `{"colorList": [{"name": "custom", "colors": [{"name": "theirs", "value": "#abcdef"}]}]}`)
+	if err := os.RemoveAll(filepath.Join(os.Getenv("HOME"), ".config", "cpick", "cache")); err != nil {
+		t.Fatal(err)
+	}
+	writeConfigFile(t, "cache", "")
+	if text := named(); !strings.Contains(text, "theirs") || !strings.Contains(text, "Could not cache the colors") {
+		t.Errorf("The error of an unwritable cache is not properly shown!\nOutput: %v\n", text)
 	}
 }
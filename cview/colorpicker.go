@@ -0,0 +1,322 @@
+package cview
+
+import (
+	"math"
+	"sync"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/lucasb-eyer/go-colorful"
+)
+
+// The width of the hue bar of a ColorPicker, including the gap between the
+// saturation-value area and the bar.
+const colorPickerHueBarWidth = 3
+
+// ColorPicker lets the user pick a color from a saturation-value area and a
+// hue bar. The saturation increases from left to right and the value decreases
+// from top to bottom in the saturation-value area, which takes up most of the
+// picker. The hue bar is shown on the right.
+//
+// The following keys can be used for navigation:
+//
+//   - h, left arrow: Decrease the saturation.
+//   - l, right arrow: Increase the saturation.
+//   - k, up arrow: Increase the value.
+//   - j, down arrow: Decrease the value.
+//   - [, page up: Move up the hue bar.
+//   - ], page down: Move down the hue bar.
+//   - Enter: Select the color.
+//
+// The mouse can be used to click or drag on the saturation-value area and the
+// hue bar. Double clicking selects the color.
+type ColorPicker struct {
+	*Box
+
+	// The hue (0-360), saturation (0-1), and value (0-1) of the color.
+	hue, saturation, value float64
+
+	// The size of the saturation-value area the last time the picker was drawn.
+	areaWidth, areaHeight int
+
+	// Which part of the picker is being dragged with the mouse: 0 for none, 1
+	// for the saturation-value area, and 2 for the hue bar.
+	dragging int
+
+	// An optional function which is called when the color changes.
+	changed func(r, g, b uint8)
+
+	// An optional function which is called when the user selects the color.
+	selected func(r, g, b uint8)
+
+	// An optional function which is called when the user presses Escape, Tab,
+	// or Backtab.
+	done func(key tcell.Key)
+
+	sync.RWMutex
+}
+
+// NewColorPicker returns a new color picker, starting at pure red.
+func NewColorPicker() *ColorPicker {
+	return &ColorPicker{
+		Box:        NewBox(),
+		saturation: 1,
+		value:      1,
+	}
+}
+
+// SetColor sets the color of the picker.
+func (c *ColorPicker) SetColor(r, g, b uint8) {
+	c.Lock()
+	defer c.Unlock()
+
+	h, s, v := colorful.Color{R: float64(r) / 255, G: float64(g) / 255, B: float64(b) / 255}.Hsv()
+
+	// Grays have no hue, so keep the current one
+	if s > 0 {
+		c.hue = h
+	}
+	c.saturation, c.value = s, v
+}
+
+// GetColor returns the color of the picker.
+func (c *ColorPicker) GetColor() (r, g, b uint8) {
+	c.RLock()
+	defer c.RUnlock()
+
+	return c.rgb()
+}
+
+// SetChangedFunc sets a handler which is called when the color of the picker
+// changes through user input.
+func (c *ColorPicker) SetChangedFunc(handler func(r, g, b uint8)) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.changed = handler
+}
+
+// SetSelectedFunc sets a handler which is called when the user selects the
+// color by pressing Enter or double clicking.
+func (c *ColorPicker) SetSelectedFunc(handler func(r, g, b uint8)) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.selected = handler
+}
+
+// SetDoneFunc sets a handler which is called when the user presses Escape,
+// Tab, or Backtab. The key is passed to the handler.
+func (c *ColorPicker) SetDoneFunc(handler func(key tcell.Key)) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.done = handler
+}
+
+// rgb returns the color of the picker as RGB values.
+func (c *ColorPicker) rgb() (r, g, b uint8) {
+	return colorful.Hsv(c.hue, c.saturation, c.value).Clamped().RGB255()
+}
+
+// clamp returns v limited to the range [low, high].
+func clamp(v, low, high float64) float64 {
+	return math.Max(low, math.Min(high, v))
+}
+
+// steps returns the amount that one cell of the saturation-value area changes
+// the saturation and value, and the amount that one row of the hue bar
+// changes the hue.
+func (c *ColorPicker) steps() (saturation, value, hue float64) {
+	saturation, value, hue = 0.01, 0.01, 1
+	if c.areaWidth > 1 {
+		saturation = 1 / float64(c.areaWidth-1)
+	}
+	if c.areaHeight > 1 {
+		value = 1 / float64(c.areaHeight-1)
+	}
+	if c.areaHeight > 0 {
+		hue = 360 / float64(c.areaHeight)
+	}
+	return
+}
+
+// Draw draws this primitive onto the screen.
+func (c *ColorPicker) Draw(screen tcell.Screen) {
+	if !c.GetVisible() {
+		return
+	}
+
+	c.Box.Draw(screen)
+
+	c.Lock()
+	defer c.Unlock()
+
+	x, y, width, height := c.GetInnerRect()
+	if width <= colorPickerHueBarWidth || height < 1 {
+		return
+	}
+
+	c.areaWidth, c.areaHeight = width-colorPickerHueBarWidth, height
+	saturationStep, valueStep, hueStep := c.steps()
+
+	// Draw the saturation-value area, marking the current color.
+	cursorX := int(math.Round(c.saturation / saturationStep))
+	cursorY := int(math.Round((1 - c.value) / valueStep))
+	for row := 0; row < c.areaHeight; row++ {
+		for column := 0; column < c.areaWidth; column++ {
+			r, g, b := colorful.Hsv(c.hue, float64(column)*saturationStep, 1-float64(row)*valueStep).Clamped().RGB255()
+			style := tcell.StyleDefault.Background(tcell.NewRGBColor(int32(r), int32(g), int32(b)))
+
+			ch := ' '
+			if column == cursorX && row == cursorY {
+				ch = '+'
+				style = style.Foreground(colorPickerContrast(r, g, b))
+			}
+			screen.SetContent(x+column, y+row, ch, nil, style)
+		}
+	}
+
+	// Draw the hue bar, marking the current hue.
+	hueRow := int(c.hue / hueStep)
+	for row := 0; row < height; row++ {
+		r, g, b := colorful.Hsv(float64(row)*hueStep, 1, 1).Clamped().RGB255()
+		style := tcell.StyleDefault.Background(tcell.NewRGBColor(int32(r), int32(g), int32(b)))
+
+		if row == hueRow {
+			screen.SetContent(x+c.areaWidth, y+row, '▶', nil, tcell.StyleDefault.Background(c.backgroundColor).Foreground(Styles.PrimaryTextColor))
+		}
+		for column := 1; column < colorPickerHueBarWidth; column++ {
+			screen.SetContent(x+c.areaWidth+column, y+row, ' ', nil, style)
+		}
+	}
+}
+
+// colorPickerContrast returns black or white, whichever is easier to read on
+// the given color.
+func colorPickerContrast(r, g, b uint8) tcell.Color {
+	if 0.299*float64(r)+0.587*float64(g)+0.114*float64(b) > 128 {
+		return tcell.ColorBlack.TrueColor()
+	}
+	return tcell.ColorWhite.TrueColor()
+}
+
+// InputHandler returns the handler for this primitive.
+func (c *ColorPicker) InputHandler() func(event *tcell.EventKey, setFocus func(p Primitive)) {
+	return c.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p Primitive)) {
+		c.Lock()
+
+		if HitShortcut(event, Keys.Select) {
+			r, g, b := c.rgb()
+			selected := c.selected
+			c.Unlock()
+			if selected != nil {
+				selected(r, g, b)
+			}
+			return
+		} else if HitShortcut(event, Keys.Cancel, Keys.MovePreviousField, Keys.MoveNextField) {
+			done := c.done
+			c.Unlock()
+			if done != nil {
+				done(event.Key())
+			}
+			return
+		}
+
+		saturationStep, valueStep, hueStep := c.steps()
+		hue, saturation, value := c.hue, c.saturation, c.value
+
+		if HitShortcut(event, Keys.MoveLeft, Keys.MoveLeft2) {
+			c.saturation = clamp(c.saturation-saturationStep, 0, 1)
+		} else if HitShortcut(event, Keys.MoveRight, Keys.MoveRight2) {
+			c.saturation = clamp(c.saturation+saturationStep, 0, 1)
+		} else if HitShortcut(event, Keys.MoveUp, Keys.MoveUp2) {
+			c.value = clamp(c.value+valueStep, 0, 1)
+		} else if HitShortcut(event, Keys.MoveDown, Keys.MoveDown2) {
+			c.value = clamp(c.value-valueStep, 0, 1)
+		} else if HitShortcut(event, Keys.MovePreviousPage) || (event.Key() == tcell.KeyRune && event.Rune() == '[') {
+			c.hue = clamp(c.hue-hueStep, 0, 360-hueStep)
+		} else if HitShortcut(event, Keys.MoveNextPage) || (event.Key() == tcell.KeyRune && event.Rune() == ']') {
+			c.hue = clamp(c.hue+hueStep, 0, 360-hueStep)
+		}
+
+		c.notifyChanged(hue, saturation, value)
+	})
+}
+
+// notifyChanged calls the changed handler if the color is different from the
+// given color. The picker must be locked, and it is unlocked when this
+// function returns.
+func (c *ColorPicker) notifyChanged(hue, saturation, value float64) {
+	if c.changed == nil || (c.hue == hue && c.saturation == saturation && c.value == value) {
+		c.Unlock()
+		return
+	}
+
+	r, g, b := c.rgb()
+	changed := c.changed
+	c.Unlock()
+	changed(r, g, b)
+}
+
+// MouseHandler returns the mouse handler for this primitive.
+func (c *ColorPicker) MouseHandler() func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
+	return c.WrapMouseHandler(func(action MouseAction, event *tcell.EventMouse, setFocus func(p Primitive)) (consumed bool, capture Primitive) {
+		x, y := event.Position()
+		if !c.InRect(x, y) && c.dragging == 0 {
+			return false, nil
+		}
+
+		c.Lock()
+
+		rectX, rectY, _, _ := c.GetInnerRect()
+		column, row := x-rectX, y-rectY
+
+		switch action {
+		case MouseLeftDown:
+			setFocus(c)
+			c.dragging = 1
+			if column >= c.areaWidth {
+				c.dragging = 2
+			}
+			capture = c
+		case MouseMove:
+			if c.dragging == 0 {
+				c.Unlock()
+				return false, nil
+			}
+			capture = c
+		case MouseLeftUp:
+			if c.dragging == 0 {
+				c.Unlock()
+				return c.InRect(x, y), nil
+			}
+		case MouseLeftDoubleClick:
+			r, g, b := c.rgb()
+			selected := c.selected
+			c.Unlock()
+			if selected != nil {
+				selected(r, g, b)
+			}
+			return true, nil
+		default:
+			c.Unlock()
+			return c.InRect(x, y), nil
+		}
+
+		// Move to the position of the mouse.
+		hue, saturation, value := c.hue, c.saturation, c.value
+		saturationStep, valueStep, hueStep := c.steps()
+		if c.dragging == 2 {
+			c.hue = clamp(float64(row)*hueStep, 0, 360-hueStep)
+		} else {
+			c.saturation = clamp(float64(column)*saturationStep, 0, 1)
+			c.value = clamp(1-float64(row)*valueStep, 0, 1)
+		}
+		if action == MouseLeftUp {
+			c.dragging = 0
+		}
+
+		c.notifyChanged(hue, saturation, value)
+		return true, capture
+	})
+}
@@ -0,0 +1,79 @@
+package cview
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestColorPicker(t *testing.T) {
+	t.Parallel()
+
+	// Initialize
+
+	c := NewColorPicker()
+	if r, g, b := c.GetColor(); r != 255 || g != 0 || b != 0 {
+		t.Errorf("failed to initialize ColorPicker: expected 255,0,0, got %d,%d,%d", r, g, b)
+	}
+
+	// Set color
+
+	c.SetColor(51, 102, 255)
+	if r, g, b := c.GetColor(); r != 51 || g != 102 || b != 255 {
+		t.Errorf("failed to set ColorPicker color: expected 51,102,255, got %d,%d,%d", r, g, b)
+	}
+
+	// Draw
+
+	app, err := newTestApp(c)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+	}
+
+	c.SetColor(255, 0, 0)
+	c.SetRect(0, 0, 14, 11)
+	c.Draw(app.screen)
+
+	if ch, _, _, _ := app.screen.GetContent(10, 0); ch != '+' {
+		t.Errorf("failed to draw ColorPicker cursor: expected +, got %c", ch)
+	}
+	if ch, _, _, _ := app.screen.GetContent(11, 0); ch != '▶' {
+		t.Errorf("failed to draw ColorPicker hue marker: expected ▶, got %c", ch)
+	}
+
+	// Navigate
+
+	var changed, selected [3]uint8
+	c.SetChangedFunc(func(r, g, b uint8) { changed = [3]uint8{r, g, b} })
+	c.SetSelectedFunc(func(r, g, b uint8) { selected = [3]uint8{r, g, b} })
+
+	handler := c.InputHandler()
+	for i := 0; i < 5; i++ {
+		handler(tcell.NewEventKey(tcell.KeyRune, 'j', tcell.ModNone), nil)
+	}
+	if changed != [3]uint8{128, 0, 0} {
+		t.Errorf("failed to change ColorPicker value: expected 128,0,0, got %v", changed)
+	}
+
+	handler(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), nil)
+	if selected != changed {
+		t.Errorf("failed to select ColorPicker color: expected %v, got %v", changed, selected)
+	}
+
+	// Click on the saturation-value area and the hue bar
+
+	mouse := c.MouseHandler()
+	setFocus := func(p Primitive) {}
+	mouse(MouseLeftDown, tcell.NewEventMouse(0, 0, tcell.Button1, tcell.ModNone), setFocus)
+	mouse(MouseLeftUp, tcell.NewEventMouse(0, 0, tcell.ButtonNone, tcell.ModNone), setFocus)
+	if changed != [3]uint8{255, 255, 255} {
+		t.Errorf("failed to click on ColorPicker saturation-value area: expected 255,255,255, got %v", changed)
+	}
+
+	c.SetColor(255, 0, 0)
+	mouse(MouseLeftDown, tcell.NewEventMouse(12, 4, tcell.Button1, tcell.ModNone), setFocus)
+	mouse(MouseLeftUp, tcell.NewEventMouse(12, 4, tcell.ButtonNone, tcell.ModNone), setFocus)
+	if r, g, b := c.GetColor(); r != 0 || g != 255 || b != 46 {
+		t.Errorf("failed to click on ColorPicker hue bar: expected 0,255,46, got %d,%d,%d", r, g, b)
+	}
+}
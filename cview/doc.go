@@ -25,6 +25,7 @@ The following widgets are available:
 
   Button - Button which is activated when the user selects it.
   CheckBox - Selectable checkbox for boolean values.
+  ColorPicker - A saturation-value area and hue bar for picking a color.
   DropDown - Drop-down selection field.
   Flex - A Flexbox based layout manager.
   Form - Form composed of input fields, drop down selections, checkboxes, and
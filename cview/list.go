@@ -191,6 +191,9 @@ type List struct {
 	// An optional function which is called when the user presses the Escape key.
 	done func()
 
+	// An optional function which decides whether or not an item is shown.
+	filter func(item *ListItem) bool
+
 	// The height of the list the last time it was drawn.
 	height int
 
@@ -519,6 +522,88 @@ func (l *List) SetDoneFunc(handler func()) {
 	l.done = handler
 }
 
+// SetFilterFunc sets a function which decides whether or not an item is shown.
+// Items for which the function returns false are not drawn and are skipped
+// when navigating the list. If the current item does not pass the filter, the
+// closest item that does is selected. Provide nil to show all items.
+//
+// Calling this function triggers a "changed" event if the selection changes.
+func (l *List) SetFilterFunc(handler func(item *ListItem) bool) {
+	l.Lock()
+
+	l.filter = handler
+
+	previousItem := l.currentItem
+	if l.currentItem < len(l.items) && !l.selectable(l.items[l.currentItem]) {
+		for distance := 1; distance < len(l.items); distance++ {
+			if index := previousItem + distance; index < len(l.items) && l.selectable(l.items[index]) {
+				l.currentItem = index
+				break
+			}
+			if index := previousItem - distance; index >= 0 && l.selectable(l.items[index]) {
+				l.currentItem = index
+				break
+			}
+		}
+	}
+
+	l.updateOffset()
+
+	if l.currentItem != previousItem && l.changed != nil {
+		item := l.items[l.currentItem]
+		l.Unlock()
+		l.changed(l.currentItem, item)
+	} else {
+		l.Unlock()
+	}
+}
+
+// visible returns whether or not an item passes the filter.
+func (l *List) visible(item *ListItem) bool {
+	return l.filter == nil || l.filter(item)
+}
+
+// visiblePosition returns the number of items before the given index which
+// pass the filter.
+func (l *List) visiblePosition(index int) int {
+	if l.filter == nil {
+		return index
+	}
+
+	var position int
+	for i := 0; i < index && i < len(l.items); i++ {
+		if l.visible(l.items[i]) {
+			position++
+		}
+	}
+	return position
+}
+
+// visibleIndex returns the index of the item at the given position among the
+// items which pass the filter, or the number of items if there is no such
+// item.
+func (l *List) visibleIndex(position int) int {
+	if l.filter == nil || position <= 0 {
+		return position
+	}
+
+	for index, item := range l.items {
+		if !l.visible(item) {
+			continue
+		}
+		if position == 0 {
+			return index
+		}
+		position--
+	}
+	return len(l.items)
+}
+
+// selectable returns whether or not an item can be navigated to.
+func (l *List) selectable(item *ListItem) bool {
+	return !item.disabled && l.visible(item) && (item.shortcut > 0 || len(item.mainText) > 0 || len(item.secondaryText) > 0)
+}
+
 // AddItem calls InsertItem() with an index of -1.
 func (l *List) AddItem(item *ListItem) {
 	l.InsertItem(-1, item)
@@ -722,6 +807,7 @@ func (l *List) Transform(tr Transformation) {
 
 func (l *List) transform(tr Transformation) {
 	var decreasing bool
+	previousItem := l.currentItem
 
 	pageItems := l.height
 	if l.showSecondaryText {
@@ -735,9 +821,9 @@ func (l *List) transform(tr Transformation) {
 	case TransformFirstItem:
 		l.currentItem = 0
 		l.itemOffset = 0
-		decreasing = true
 	case TransformLastItem:
 		l.currentItem = len(l.items) - 1
+		decreasing = true
 	case TransformPreviousItem:
 		l.currentItem--
 		decreasing = true
@@ -768,8 +854,7 @@ func (l *List) transform(tr Transformation) {
 			}
 		}
 
-		item := l.items[l.currentItem]
-		if !item.disabled && (item.shortcut > 0 || len(item.mainText) > 0 || len(item.secondaryText) > 0) {
+		if l.selectable(l.items[l.currentItem]) {
 			break
 		}
 
@@ -780,6 +865,11 @@ func (l *List) transform(tr Transformation) {
 		}
 	}
 
+	// Stay on the previous item if there was no item to move to.
+	if (l.currentItem < 0 || l.currentItem >= len(l.items) || !l.selectable(l.items[l.currentItem])) && previousItem >= 0 && previousItem < len(l.items) {
+		l.currentItem = previousItem
+	}
+
 	l.updateOffset()
 }
 
@@ -791,31 +881,36 @@ func (l *List) updateOffset() {
 		h /= 2
 	}
 
-	if l.currentItem < l.itemOffset {
-		l.itemOffset = l.currentItem
+	// Work out the offset from the positions among the items that pass the
+	// filter, since the other items aren't drawn.
+	current, offset, total := l.visiblePosition(l.currentItem), l.visiblePosition(l.itemOffset), l.visiblePosition(len(l.items))
+
+	if current < offset {
+		offset = current
 	} else if l.showSecondaryText {
-		if 2*(l.currentItem-l.itemOffset) >= h-1 {
-			l.itemOffset = (2*l.currentItem + 3 - h) / 2
+		if 2*(current-offset) >= h-1 {
+			offset = (2*current + 3 - h) / 2
 		}
 	} else {
-		if l.currentItem-l.itemOffset >= h {
-			l.itemOffset = l.currentItem + 1 - h
+		if current-offset >= h {
+			offset = current + 1 - h
 		}
 	}
 
 	if l.showSecondaryText {
-		if l.itemOffset > len(l.items)-(l.height/2) {
-			l.itemOffset = len(l.items) - l.height/2
+		if offset > total-(l.height/2) {
+			offset = total - l.height/2
 		}
 	} else {
-		if l.itemOffset > len(l.items)-l.height {
-			l.itemOffset = len(l.items) - l.height
+		if offset > total-l.height {
+			offset = total - l.height
 		}
 	}
 
-	if l.itemOffset < 0 {
-		l.itemOffset = 0
+	if offset < 0 {
+		offset = 0
 	}
+	l.itemOffset = l.visibleIndex(offset)
 
 	// Maximum width of item text
 	maxWidth := 0
@@ -838,8 +933,8 @@ func (l *List) updateOffset() {
 	addWidth := 0
 	if l.scrollBarVisibility == ScrollBarAlways ||
 		(l.scrollBarVisibility == ScrollBarAuto &&
-			((!l.showSecondaryText && len(l.items) > l.innerHeight) ||
-				(l.showSecondaryText && len(l.items) > l.innerHeight/2))) {
+			((!l.showSecondaryText && total > l.innerHeight) ||
+				(l.showSecondaryText && total > l.innerHeight/2))) {
 		addWidth = 1
 	}
 
@@ -897,13 +992,17 @@ func (l *List) Draw(screen tcell.Screen) {
 		l.updateOffset()
 	}
 
-	scrollBarCursor := int(float64(len(l.items)) * (float64(l.itemOffset) / float64(len(l.items)-height)))
+	// The scroll bar only counts the items which pass the filter.
+	offset, total := l.visiblePosition(l.itemOffset), l.visiblePosition(len(l.items))
+	scrollBarCursor := int(float64(total) * (float64(offset) / float64(total-height)))
 
 	// Draw the list items.
+	var drawn int
 	for index, item := range l.items {
-		if index < l.itemOffset {
+		if index < l.itemOffset || !l.visible(item) {
 			continue
 		}
+		drawn++
 
 		if y >= bottomLimit {
 			break
@@ -927,7 +1026,7 @@ func (l *List) Draw(screen tcell.Screen) {
 		if len(item.mainText) == 0 && len(item.secondaryText) == 0 && item.shortcut == 0 { // Divider
 			Print(screen, bytes.Repeat([]byte(string(tcell.RuneHLine)), width+l.paddingLeft+l.paddingRight), x-l.paddingLeft, y, width+l.paddingLeft+l.paddingRight, AlignLeft, l.mainTextColor)
 
-			RenderScrollBar(screen, l.scrollBarVisibility, scrollBarX, y, scrollBarHeight, len(l.items), scrollBarCursor, index-l.itemOffset, l.hasFocus, l.scrollBarColor)
+			RenderScrollBar(screen, l.scrollBarVisibility, scrollBarX, y, scrollBarHeight, total, scrollBarCursor, drawn-1, l.hasFocus, l.scrollBarColor)
 			y++
 			continue
 		} else if item.disabled {
@@ -939,7 +1038,7 @@ func (l *List) Draw(screen tcell.Screen) {
 			// Main text.
 			Print(screen, mainText, x, y, width, AlignLeft, tcell.ColorGray.TrueColor())
 
-			RenderScrollBar(screen, l.scrollBarVisibility, scrollBarX, y, scrollBarHeight, len(l.items), scrollBarCursor, index-l.itemOffset, l.hasFocus, l.scrollBarColor)
+			RenderScrollBar(screen, l.scrollBarVisibility, scrollBarX, y, scrollBarHeight, total, scrollBarCursor, drawn-1, l.hasFocus, l.scrollBarColor)
 			y++
 			continue
 		}
@@ -972,7 +1071,7 @@ func (l *List) Draw(screen tcell.Screen) {
 			}
 		}
 
-		RenderScrollBar(screen, l.scrollBarVisibility, scrollBarX, y, scrollBarHeight, len(l.items), scrollBarCursor, index-l.itemOffset, l.hasFocus, l.scrollBarColor)
+		RenderScrollBar(screen, l.scrollBarVisibility, scrollBarX, y, scrollBarHeight, total, scrollBarCursor, drawn-1, l.hasFocus, l.scrollBarColor)
 
 		y++
 
@@ -984,7 +1083,7 @@ func (l *List) Draw(screen tcell.Screen) {
 		if l.showSecondaryText {
 			Print(screen, secondaryText, x, y, width, AlignLeft, l.secondaryTextColor)
 
-			RenderScrollBar(screen, l.scrollBarVisibility, scrollBarX, y, scrollBarHeight, len(l.items), scrollBarCursor, index-l.itemOffset, l.hasFocus, l.scrollBarColor)
+			RenderScrollBar(screen, l.scrollBarVisibility, scrollBarX, y, scrollBarHeight, total, scrollBarCursor, drawn-1, l.hasFocus, l.scrollBarColor)
 
 			y++
 		}
@@ -992,7 +1091,7 @@ func (l *List) Draw(screen tcell.Screen) {
 
 	// Overdraw scroll bar when necessary.
 	for y < bottomLimit {
-		RenderScrollBar(screen, l.scrollBarVisibility, scrollBarX, y, scrollBarHeight, len(l.items), scrollBarCursor, bottomLimit-y, l.hasFocus, l.scrollBarColor)
+		RenderScrollBar(screen, l.scrollBarVisibility, scrollBarX, y, scrollBarHeight, total, scrollBarCursor, bottomLimit-y, l.hasFocus, l.scrollBarColor)
 
 		y++
 	}
@@ -1112,7 +1211,7 @@ func (l *List) InputHandler() func(event *tcell.EventKey, setFocus func(p Primit
 			if ch != ' ' {
 				// It's not a space bar. Is it a shortcut?
 				for index, item := range l.items {
-					if !item.disabled && item.shortcut == ch {
+					if !item.disabled && l.visible(item) && item.shortcut == ch {
 						// We have a shortcut.
 						l.currentItem = index
 
@@ -1175,16 +1274,7 @@ func (l *List) indexAtY(y int) int {
 		return -1
 	}
 
-	index := y - rectY
-	if l.showSecondaryText {
-		index /= 2
-	}
-	index += l.itemOffset
-
-	if index >= len(l.items) {
-		return -1
-	}
-	return index
+	return l.indexAtRow(y - rectY)
 }
 
 // indexAtPoint returns the index of the list item found at the given position
@@ -1195,16 +1285,27 @@ func (l *List) indexAtPoint(x, y int) int {
 		return -1
 	}
 
-	index := y - rectY
+	return l.indexAtRow(y - rectY)
+}
+
+// indexAtRow returns the index of the list item drawn at the given row, where
+// 0 is the first row of the list, or a negative value if there is no such list
+// item. Items that don't pass the filter are skipped.
+func (l *List) indexAtRow(row int) int {
 	if l.showSecondaryText {
-		index /= 2
+		row /= 2
 	}
-	index += l.itemOffset
 
-	if index >= len(l.items) {
-		return -1
+	for index := l.itemOffset; index < len(l.items); index++ {
+		if !l.visible(l.items[index]) {
+			continue
+		}
+		if row == 0 {
+			return index
+		}
+		row--
 	}
-	return index
+	return -1
 }
 
 // MouseHandler returns the mouse handler for this primitive.
@@ -1307,17 +1408,18 @@ func (l *List) MouseHandler() func(action MouseAction, event *tcell.EventMouse,
 				consumed = true
 			}
 		case MouseScrollUp:
-			if l.itemOffset > 0 {
-				l.itemOffset--
+			if offset := l.visiblePosition(l.itemOffset); offset > 0 {
+				l.itemOffset = l.visibleIndex(offset - 1)
 			}
 			consumed = true
 		case MouseScrollDown:
-			lines := len(l.items) - l.itemOffset
+			offset := l.visiblePosition(l.itemOffset)
+			lines := l.visiblePosition(len(l.items)) - offset
 			if l.showSecondaryText {
 				lines *= 2
 			}
 			if _, _, _, height := l.GetInnerRect(); lines > height {
-				l.itemOffset++
+				l.itemOffset = l.visibleIndex(offset + 1)
 			}
 			consumed = true
 		}
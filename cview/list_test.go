@@ -1,6 +1,7 @@
 package cview
 
 import (
+	"fmt"
 	"testing"
 )
 
@@ -73,3 +74,125 @@ func TestList(t *testing.T) {
 
 	l.Draw(app.screen)
 }
+
+func TestListFilter(t *testing.T) {
+	t.Parallel()
+
+	l := NewList()
+	for _, text := range []string{listTextA, listTextB, listTextC, listTextB} {
+		l.AddItem(NewListItem(text))
+	}
+	l.SetCurrentItem(1)
+
+	// Only show the items that say hello
+
+	hello := func(item *ListItem) bool {
+		return item.GetMainText() != listTextB
+	}
+	l.SetFilterFunc(hello)
+	if l.GetCurrentItemIndex() != 2 {
+		t.Errorf("failed to filter List: expected current item 2, got %d", l.GetCurrentItemIndex())
+	}
+
+	// Navigate without wrapping around
+
+	l.Transform(TransformNextItem)
+	if l.GetCurrentItemIndex() != 2 {
+		t.Errorf("failed to navigate filtered List: expected current item 2, got %d", l.GetCurrentItemIndex())
+	}
+	l.Transform(TransformPreviousItem)
+	if l.GetCurrentItemIndex() != 0 {
+		t.Errorf("failed to navigate filtered List: expected current item 0, got %d", l.GetCurrentItemIndex())
+	}
+	l.Transform(TransformPreviousItem)
+	if l.GetCurrentItemIndex() != 0 {
+		t.Errorf("failed to navigate filtered List: expected current item 0, got %d", l.GetCurrentItemIndex())
+	}
+	l.Transform(TransformLastItem)
+	if l.GetCurrentItemIndex() != 2 {
+		t.Errorf("failed to navigate filtered List: expected current item 2, got %d", l.GetCurrentItemIndex())
+	}
+
+	// Navigate with wrapping around
+
+	l.SetWrapAround(true)
+	l.Transform(TransformNextItem)
+	if l.GetCurrentItemIndex() != 0 {
+		t.Errorf("failed to wrap around filtered List: expected current item 0, got %d", l.GetCurrentItemIndex())
+	}
+	l.Transform(TransformPreviousItem)
+	if l.GetCurrentItemIndex() != 2 {
+		t.Errorf("failed to wrap around filtered List: expected current item 2, got %d", l.GetCurrentItemIndex())
+	}
+
+	// Draw only the filtered items
+
+	app, err := newTestApp(l)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+	}
+
+	l.ShowSecondaryText(false)
+	l.SetRect(0, 0, 20, 4)
+	l.Draw(app.screen)
+	if index := l.indexAtY(1); index != 2 {
+		t.Errorf("failed to draw filtered List: expected item 2 on the second row, got %d", index)
+	}
+	if ch, _, _, _ := app.screen.GetContent(7, 1); ch != 'D' {
+		t.Errorf("failed to draw filtered List: expected %s on the second row, got %c", listTextC, ch)
+	}
+}
+
+func TestListFilterOffset(t *testing.T) {
+	t.Parallel()
+
+	l := NewList()
+	l.ShowSecondaryText(false)
+	for i := 0; i < 60; i++ {
+		l.AddItem(NewListItem(fmt.Sprintf("item %d", i)))
+	}
+
+	app, err := newTestApp(l)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+	}
+	l.SetRect(0, 0, 20, 10)
+
+	// Only four items are shown, so they all fit without scrolling
+	l.SetFilterFunc(func(item *ListItem) bool {
+		var i int
+		fmt.Sscanf(item.GetMainText(), "item %d", &i)
+		return i < 3 || i == 50
+	})
+	l.SetCurrentItem(50)
+	l.Draw(app.screen)
+	if items, _ := l.GetOffset(); items != 0 {
+		t.Errorf("failed to scroll filtered List: expected offset 0, got %d", items)
+	}
+	if index := l.indexAtY(0); index != 0 {
+		t.Errorf("failed to scroll filtered List: expected item 0 on the first row, got %d", index)
+	}
+	if index := l.indexAtY(3); index != 50 {
+		t.Errorf("failed to scroll filtered List: expected item 50 on the fourth row, got %d", index)
+	}
+
+	// Scroll a list with more shown items than rows
+	l.SetFilterFunc(func(item *ListItem) bool {
+		var i int
+		fmt.Sscanf(item.GetMainText(), "item %d", &i)
+		return i%2 == 0
+	})
+	l.Draw(app.screen)
+	if items, _ := l.GetOffset(); items != 32 {
+		t.Errorf("failed to scroll filtered List: expected offset 32, got %d", items)
+	}
+	if index := l.indexAtY(9); index != 50 {
+		t.Errorf("failed to scroll filtered List: expected item 50 on the last row, got %d", index)
+	}
+
+	l.SetCurrentItem(10)
+	l.Draw(app.screen)
+	if index := l.indexAtY(0); index != 10 {
+		t.Errorf("failed to scroll filtered List: expected item 10 on the first row, got %d", index)
+	}
+}
@@ -693,6 +693,39 @@ func (t *Table) GetRowCount() int {
 	return len(t.cells)
 }
 
+// contentWidth returns the number of screen cells needed to show every
+// column of the table, with the column widths taken from every row.
+func (t *Table) contentWidth() int {
+	columnPadding := t.columnPadding
+	if t.cellBorders {
+		columnPadding++
+	}
+
+	var tableWidth int
+	for column := 0; column <= t.lastColumn; column++ {
+		maxWidth := 0
+		for _, row := range t.cells {
+			if column >= len(row) || row[column] == nil {
+				continue
+			}
+			cell := row[column]
+			_, _, _, _, _, _, cellWidth := decomposeText(cell.Text, true, false)
+			if cell.MaxWidth > 0 && cell.MaxWidth < cellWidth {
+				cellWidth = cell.MaxWidth
+			}
+			if cellWidth > maxWidth {
+				maxWidth = cellWidth
+			}
+		}
+		tableWidth += maxWidth + columnPadding
+	}
+
+	if t.cellBorders {
+		return tableWidth + 1 // The left table border.
+	}
+	return tableWidth - columnPadding // No separator after the last column.
+}
+
 // GetColumnCount returns the (maximum) number of columns in the table.
 func (t *Table) GetColumnCount() int {
 	t.RLock()
@@ -844,6 +877,20 @@ func (t *Table) Draw(screen tcell.Screen) {
 		width-- // Subtract space for scroll bar.
 	}
 
+	// The horizontal scroll bar takes up the last row. It is only shown when
+	// the columns don't fit (even with ScrollBarAlways, so that tables which
+	// fit keep every row), and it is decided before the visible rows are, so
+	// that the selected row isn't hidden under it.
+	showHorizontalScrollBar := height > 1 && t.scrollBarVisibility != ScrollBarNever && t.contentWidth() > width
+	if showHorizontalScrollBar {
+		height--
+		if t.cellBorders {
+			t.visibleRows = height / 2
+		} else {
+			t.visibleRows = height / (t.rowPadding + 1)
+		}
+	}
+
 	// Return the cell at the specified position (nil if it doesn't exist).
 	getCell := func(row, column int) *TableCell {
 		if row < 0 || column < 0 || row >= len(t.cells) || column >= len(t.cells[row]) {
@@ -886,7 +933,7 @@ func (t *Table) Draw(screen tcell.Screen) {
 			}
 		} else {
 			if t.selectedRow-t.rowOffset >= height/(t.rowPadding+1) {
-				t.rowOffset = t.selectedRow - height/(t.rowPadding+1)
+				t.rowOffset = t.selectedRow + 1 - height/(t.rowPadding+1)
 				t.trackEnd = false
 			}
 		}
@@ -1038,6 +1085,9 @@ ColumnLoop:
 	}
 	t.columnOffset = skipped
 
+	scrollColumns := t.lastColumn + 1 - t.fixedColumns
+	visibleScrollColumns := len(columns) - t.fixedColumns
+
 	// If we have space left, distribute it.
 	if tableWidth < width {
 		toDistribute := width - tableWidth
@@ -1190,7 +1240,37 @@ ColumnLoop:
 		}
 	}
 
-	// TODO Draw horizontal scroll bar
+	if showHorizontalScrollBar {
+		// Calculate the size and position of the handle. The handle covers the
+		// share of columns that are visible, and it is moved to the end once the
+		// last column is visible.
+		scrollBarWidth := width
+		scrollBarY := y + height
+
+		handleWidth := scrollBarWidth
+		handleX := 0
+		if scrollColumns > 0 && visibleScrollColumns < scrollColumns {
+			handleWidth = scrollBarWidth * visibleScrollColumns / scrollColumns
+			if handleWidth < 1 {
+				handleWidth = 1
+			}
+
+			if len(columns) > 0 && columns[len(columns)-1] == t.lastColumn {
+				handleX = scrollBarWidth - handleWidth
+			} else {
+				handleX = (scrollBarWidth - handleWidth) * t.columnOffset / (scrollColumns - visibleScrollColumns)
+			}
+			if handleX > scrollBarWidth-handleWidth {
+				handleX = scrollBarWidth - handleWidth
+			}
+		}
+
+		// Draw scroll bar.
+		for printed := 0; printed < scrollBarWidth; printed++ {
+			handle := printed >= handleX && printed < handleX+handleWidth
+			renderScrollBarCell(screen, x+printed, scrollBarY, handle, t.hasFocus, t.scrollBarColor)
+		}
+	}
 
 	// Helper function which colors the background of a box.
 	// backgroundColor == tcell.ColorDefault => Don't color the background.
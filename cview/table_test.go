@@ -51,6 +51,104 @@ func TestTable(t *testing.T) {
 	}
 }
 
+func TestTableHorizontalScrollBar(t *testing.T) {
+	t.Parallel()
+
+	table := NewTable()
+	for row := 0; row < 3; row++ {
+		for column := 0; column < 10; column++ {
+			table.SetCellSimple(row, column, fmt.Sprintf("column%d", column))
+		}
+	}
+
+	app, err := newTestApp(table)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+	}
+
+	table.Blur()
+	table.SetRect(0, 0, 30, 4)
+	table.SetOffset(0, 3)
+	table.Draw(app.screen)
+
+	// Five of the ten columns are visible after skipping three (the last one is
+	// cut off), so the handle is 15 cells wide and starts 9 cells in
+	for x := 0; x < 30; x++ {
+		ch, _, _, _ := app.screen.GetContent(x, 3)
+		expected := '░'
+		if x >= 9 && x < 24 {
+			expected = '▓'
+		}
+		if ch != expected {
+			t.Errorf("failed to draw horizontal scroll bar at %d: expected %c, got %c", x, expected, ch)
+		}
+	}
+
+	// The last row is taken up by the scroll bar
+	if ch, _, _, _ := app.screen.GetContent(0, 2); ch != 'c' {
+		t.Errorf("failed to draw table row above the horizontal scroll bar: got %c", ch)
+	}
+}
+
+func TestTableHorizontalScrollBarSelection(t *testing.T) {
+	t.Parallel()
+
+	table := NewTable()
+	table.SetSelectable(true, false)
+	for row := 0; row < 10; row++ {
+		for column := 0; column < 10; column++ {
+			table.SetCellSimple(row, column, fmt.Sprintf("r%dc%d", row, column))
+		}
+	}
+
+	app, err := newTestApp(table)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+	}
+
+	table.Blur()
+	table.SetRect(0, 0, 30, 4)
+	table.Select(3, 0)
+	table.Draw(app.screen)
+
+	// The selected row is scrolled into view above the scroll bar
+	var found bool
+	for y := 0; y < 3; y++ {
+		if ch, _, _, _ := app.screen.GetContent(1, y); ch == '3' {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("failed to keep the selected row visible above the horizontal scroll bar")
+	}
+	if ch, _, _, _ := app.screen.GetContent(0, 3); ch != '░' && ch != '▓' {
+		t.Errorf("failed to draw horizontal scroll bar: got %c", ch)
+	}
+}
+
+func TestTableHorizontalScrollBarFits(t *testing.T) {
+	t.Parallel()
+
+	table := NewTable()
+	table.SetScrollBarVisibility(ScrollBarAlways)
+	for row := 0; row < 4; row++ {
+		table.SetCellSimple(row, 0, fmt.Sprintf("row%d", row))
+	}
+
+	app, err := newTestApp(table)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+	}
+
+	table.SetRect(0, 0, 30, 4)
+	table.Draw(app.screen)
+
+	// A table whose columns fit keeps every row
+	if ch, _, _, _ := app.screen.GetContent(3, 3); ch != '3' {
+		t.Errorf("failed to draw the last row of a table that fits: got %c", ch)
+	}
+}
+
 func BenchmarkTableDraw(b *testing.B) {
 	for _, c := range tableTestCases {
 		c := c // Capture
@@ -3,6 +3,7 @@ package cview
 import (
 	"bytes"
 	"regexp"
+	"strconv"
 	"sync"
 	"unicode"
 	"unicode/utf8"
@@ -164,6 +165,13 @@ type TextView struct {
 	// The scroll bar color.
 	scrollBarColor tcell.Color
 
+	// If set to true, the number of each buffer line is shown in a gutter on the
+	// left.
+	lineNumbers bool
+
+	// The color of the line numbers.
+	lineNumbersColor tcell.Color
+
 	// If set to true, lines that are longer than the available width are wrapped
 	// onto the next line. If set to false, any characters beyond the available
 	// width are discarded.
@@ -190,6 +198,17 @@ type TextView struct {
 	// highlight(s) into the visible screen.
 	scrollToHighlights bool
 
+	// The buffer line indices which matched the last call to Find().
+	findMatches []int
+
+	// The index into findMatches of the current match. Set to -1 if no match
+	// has been moved to yet.
+	findCurrent int
+
+	// The buffer line which will be brought into the visible screen the next
+	// time the text view is drawn. Set to -1 if there is no such line.
+	scrollToLine int
+
 	// If true, setting new highlights will be a XOR instead of an overwrite
 	// operation.
 	toggleHighlights bool
@@ -215,10 +234,13 @@ func NewTextView() *TextView {
 		Box:                 NewBox(),
 		highlights:          make(map[string]struct{}),
 		lineOffset:          -1,
+		findCurrent:         -1,
+		scrollToLine:        -1,
 		reindex:             true,
 		scrollable:          true,
 		scrollBarVisibility: ScrollBarAuto,
 		scrollBarColor:      Styles.ScrollBarColor,
+		lineNumbersColor:    Styles.TertiaryTextColor,
 		align:               AlignLeft,
 		wrap:                true,
 		textColor:           Styles.PrimaryTextColor,
@@ -254,6 +276,27 @@ func (t *TextView) SetScrollBarColor(color tcell.Color) {
 	t.scrollBarColor = color
 }
 
+// SetLineNumbers sets the flag that, if true, shows the number of each line in
+// a gutter on the left. When a line is wrapped, the number is only shown next
+// to its first part.
+func (t *TextView) SetLineNumbers(lineNumbers bool) {
+	t.Lock()
+	defer t.Unlock()
+
+	if t.lineNumbers != lineNumbers {
+		t.index = nil
+	}
+	t.lineNumbers = lineNumbers
+}
+
+// SetLineNumbersColor sets the color of the line numbers.
+func (t *TextView) SetLineNumbersColor(color tcell.Color) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.lineNumbersColor = color
+}
+
 // SetWrap sets the flag that, if true, leads to lines that are longer than the
 // available width being wrapped onto the next line. If false, any characters
 // beyond the available width are not displayed.
@@ -470,6 +513,84 @@ func (t *TextView) ScrollToEnd() {
 	t.columnOffset = 0
 }
 
+// Find searches the text buffer for the provided query and returns the indices
+// of the buffer lines which contain it. Color and region tags are ignored when
+// matching. The matches can then be moved through with FindNext() and
+// FindPrevious().
+func (t *TextView) Find(query string, caseInsensitive bool) []int {
+	t.Lock()
+	defer t.Unlock()
+
+	t.findMatches = nil
+	t.findCurrent = -1
+	if query == "" {
+		return nil
+	}
+
+	q := []byte(query)
+	if caseInsensitive {
+		q = bytes.ToLower(q)
+	}
+	for i, line := range t.buffer {
+		text := StripTags(line, t.dynamicColors, t.regions)
+		if caseInsensitive {
+			text = bytes.ToLower(text)
+		}
+		if bytes.Contains(text, q) {
+			t.findMatches = append(t.findMatches, i)
+		}
+	}
+	return t.findMatches
+}
+
+// FindNext moves to the next match of the last call to Find(), wrapping around
+// to the first match after the last one. The matched line is scrolled into
+// view the next time the text view is drawn. If regions are enabled, the
+// regions in the matched line are highlighted. The index of the matched buffer
+// line is returned, or -1 if there are no matches.
+func (t *TextView) FindNext() int {
+	return t.moveFind(1)
+}
+
+// FindPrevious moves to the previous match of the last call to Find(),
+// wrapping around to the last match before the first one. See FindNext() for
+// details.
+func (t *TextView) FindPrevious() int {
+	return t.moveFind(-1)
+}
+
+// moveFind moves the current find match by the given amount of matches.
+func (t *TextView) moveFind(step int) int {
+	t.Lock()
+
+	if len(t.findMatches) == 0 {
+		t.Unlock()
+		return -1
+	}
+
+	if t.findCurrent < 0 && step < 0 {
+		t.findCurrent = 0
+	}
+	t.findCurrent = (t.findCurrent + step + len(t.findMatches)) % len(t.findMatches)
+	line := t.findMatches[t.findCurrent]
+	t.scrollToLine = line
+
+	var regionIDs []string
+	if t.regions && line < len(t.buffer) {
+		for _, region := range regionPattern.FindAllSubmatch(t.buffer[line], -1) {
+			if len(region[1]) > 0 {
+				regionIDs = append(regionIDs, string(region[1]))
+			}
+		}
+	}
+	t.Unlock()
+
+	if len(regionIDs) > 0 {
+		t.Highlight(regionIDs...)
+	}
+	return line
+}
+
 // GetScrollOffset returns the number of rows and columns that are skipped at
 // the top left corner when the text view has been scrolled.
 func (t *TextView) GetScrollOffset() (row, column int) {
@@ -990,6 +1111,17 @@ func (t *TextView) Draw(screen tcell.Screen) {
 	}
 	t.pageSize = height
 
+	// Reserve space for the line numbers, which are followed by a space.
+	var gutterWidth int
+	if t.lineNumbers {
+		gutterWidth = len(strconv.Itoa(len(t.buffer))) + 1
+		if gutterWidth > width {
+			gutterWidth = width
+		}
+		x += gutterWidth
+		width -= gutterWidth
+	}
+
 	if t.index == nil || width != t.lastWidth || height != t.lastHeight {
 		t.reindexBuffer(width)
 	}
@@ -1033,6 +1165,18 @@ func (t *TextView) Draw(screen tcell.Screen) {
 	}
 	t.scrollToHighlights = false
 
+	// Move to the first line of the current find match.
+	if t.scrollable && t.scrollToLine >= 0 {
+		for line, info := range t.index {
+			if info.Line == t.scrollToLine {
+				t.lineOffset = line
+				t.trackEnd = false
+				break
+			}
+		}
+	}
+	t.scrollToLine = -1
+
 	// Adjust line offset.
 	if t.lineOffset+height > len(t.index) {
 		t.trackEnd = true
@@ -1098,6 +1242,11 @@ func (t *TextView) Draw(screen tcell.Screen) {
 			})
 		}
 
+		// Draw the line number next to the first part of the line.
+		if t.lineNumbers && gutterWidth > 1 && (line == 0 || t.index[line-1].Line != index.Line) {
+			Print(screen, []byte(strconv.Itoa(index.Line+1)), x-gutterWidth, y+line-t.lineOffset, gutterWidth-1, AlignRight, t.lineNumbersColor)
+		}
+
 		// Process tags.
 		colorTagIndices, colorTags, regionIndices, regions, escapeIndices, strippedText, _ := decomposeText(text, t.dynamicColors, t.regions)
 
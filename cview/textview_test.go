@@ -353,6 +353,124 @@ func TestTextViewMaxLines(t *testing.T) {
 	}
 }
 
+func TestTextViewFind(t *testing.T) {
+	t.Parallel()
+
+	tv := NewTextView()
+	tv.SetDynamicColors(true)
+	tv.SetRegions(true)
+
+	app, err := newTestApp(tv)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+	}
+
+	// Each long line wraps onto three screen lines
+	long := bytes.Repeat([]byte("x"), 25)
+	for i := 0; i < 10; i++ {
+		_, err := tv.Write([]byte(fmt.Sprintf("%s\n", long)))
+		if err != nil {
+			t.Errorf("failed to write to TextView: %s", err)
+		}
+	}
+	_, err = tv.Write([]byte("[red]Nee[blue]dle[-]\n"))
+	if err != nil {
+		t.Errorf("failed to write to TextView: %s", err)
+	}
+	_, err = tv.Write([]byte("another [\"match\"]needle[\"\"]\n"))
+	if err != nil {
+		t.Errorf("failed to write to TextView: %s", err)
+	}
+	for i := 0; i < 10; i++ {
+		_, err := tv.Write([]byte(fmt.Sprintf("%s\n", long)))
+		if err != nil {
+			t.Errorf("failed to write to TextView: %s", err)
+		}
+	}
+
+	// Tags are ignored when matching
+	if matches := tv.Find("needle", false); len(matches) != 1 || matches[0] != 11 {
+		t.Errorf("expected case sensitive match on line 11, got %v", matches)
+	}
+	if matches := tv.Find("needle", true); len(matches) != 2 || matches[0] != 10 || matches[1] != 11 {
+		t.Errorf("expected case insensitive matches on lines 10 and 11, got %v", matches)
+	}
+
+	tv.SetRect(0, 0, 10, 5)
+
+	// The first match starts after the ten wrapped lines
+	if line := tv.FindNext(); line != 10 {
+		t.Errorf("expected FindNext to move to line 10, got %d", line)
+	}
+	tv.Draw(app.screen)
+	if row, _ := tv.GetScrollOffset(); row != 30 {
+		t.Errorf("expected to scroll to row 30, got %d", row)
+	}
+
+	// The second match is highlighted
+	if line := tv.FindNext(); line != 11 {
+		t.Errorf("expected FindNext to move to line 11, got %d", line)
+	}
+	if highlights := tv.GetHighlights(); len(highlights) != 1 || highlights[0] != "match" {
+		t.Errorf("expected the match region to be highlighted, got %v", highlights)
+	}
+
+	// Moving past the first match wraps around
+	tv.FindPrevious()
+	if line := tv.FindPrevious(); line != 11 {
+		t.Errorf("expected FindPrevious to wrap around to line 11, got %d", line)
+	}
+
+	if matches := tv.Find("missing", false); len(matches) != 0 || tv.FindNext() != -1 {
+		t.Errorf("expected no matches, got %v", matches)
+	}
+}
+
+func TestTextViewLineNumbers(t *testing.T) {
+	t.Parallel()
+
+	tv := NewTextView()
+	tv.SetLineNumbers(true)
+	tv.SetScrollBarVisibility(ScrollBarNever)
+
+	app, err := newTestApp(tv)
+	if err != nil {
+		t.Errorf("failed to initialize Application: %s", err)
+	}
+
+	_, err = tv.Write([]byte("abcdefghij\n"))
+	if err != nil {
+		t.Errorf("failed to write to TextView: %s", err)
+	}
+	for i := 2; i <= 12; i++ {
+		_, err := tv.Write([]byte(fmt.Sprintf("L%d\n", i)))
+		if err != nil {
+			t.Errorf("failed to write to TextView: %s", err)
+		}
+	}
+
+	tv.SetRect(0, 0, 10, 20)
+	tv.Draw(app.screen)
+
+	// The gutter fits two digits and a space, and the first line wraps onto a
+	// second screen line without a number
+	expected := []string{
+		" 1 abcdefg",
+		"   hij    ",
+		" 2 L2     ",
+	}
+	for y, e := range expected {
+		var line []rune
+		for x := 0; x < 10; x++ {
+			ch, _, _, _ := app.screen.GetContent(x, y)
+			line = append(line, ch)
+		}
+		if string(line) != e {
+			t.Errorf("expected line %d to be %q, got %q", y, e, string(line))
+		}
+	}
+}
+
 func generateTestCases() []*textViewTestCase {
 	var cases []*textViewTestCase
 	for i := 0; i < 2; i++ {
@@ -745,8 +745,14 @@ func RenderScrollBar(screen tcell.Screen, visibility ScrollBarVisibility, x int,
 	handlePosition := int(float64(height-1) * (float64(cursor) / float64(items-1)))
 
 	// Print scroll bar.
+	renderScrollBarCell(screen, x, y, printed == handlePosition, focused, color)
+}
+
+// renderScrollBarCell renders a single cell of a scroll bar, which is either
+// part of the handle or part of the area.
+func renderScrollBarCell(screen tcell.Screen, x int, y int, handle bool, focused bool, color tcell.Color) {
 	var text []byte
-	if printed == handlePosition {
+	if handle {
 		if focused {
 			text = ScrollBarHandleFocused
 		} else {
@@ -5,41 +5,93 @@ Package cpick is a advanced color picker that can be run from the terminal.
 
 Help screen:
 
-  - View help screen: Backtick (`)
+  - View help screen: Backtick (`) (scroll with j/k and press Escape or Enter to exit)
+
+For every table:
+
+  - Messages such as copy confirmations, save results, and errors are shown on the status line at the bottom of the screen for a few seconds (errors are shown in red)
+  - Copy the highlighted color to the clipboard: Press y
+  - Copy the truecolor escape sequence of the highlighted color to the clipboard: Press Y (EX: \033[38;2;255;0;0m, which can be pasted into a shell to test the color; the status line shows what was copied)
+  - Reload the preset colors after editing colors.json: Press F5
+  - Show the complementary, triadic, and analogous colors of the highlighted color: Press H (press H or Escape to go back and Enter to select a color)
+  - Simulate color blindness: Press V to cycle through protanopia, deuteranopia, tritanopia, and no simulation
+  - Switch between drawing colors in truecolor and in 256 colors: Press M (use 256 colors if the terminal doesn't draw truecolor properly; only how the colors are drawn changes, not the selected color)
+  - Jump to a random color: Press r (a random hue is drawn on the saturation-value table and a random cell of it is selected)
+  - Switch to grayscale mode: Press w (the saturation-value table will only contain grays and the hue screen is disabled; press w again to leave)
+  - Pick from the 256 color terminal palette: Press X (press X or Escape to go back and Enter to select a color; the selected color uses the 256 color escape code)
+  - Pick one of the 16 standard terminal colors: Press x (press x or Escape to go back and Enter to select a color; the selected color uses the standard escape code, such as 31 for red)
+  - Adjust the highlighted color with hue, saturation, and value sliders: Press S (press h/l or click to move a slider, Tab to switch sliders, Enter to select the color, and S or Escape to go back)
+  - Make a gradient between two colors: Press e on the start color and then on the end color (press + and - to change the number of colors, Enter to select a color, and e or Escape to go back)
+  - Pick from an alphabetical list of every named preset color: Press I (each name is shown with a swatch and its hex value; press Enter to select a color and I or Escape to go back)
+  - Adjust the highlighted color for print with cyan, magenta, yellow, and black sliders: Press K (a swatch shows an approximation of the printed color and the total amount of ink, which turns red above 300%; press Enter to select the color and K or Escape to go back)
+  - Add the highlighted color to the favorites: Press b (favorites are saved in ~/.config/cpick/favorites.json)
+  - Show the favorite colors: Press B (press Enter to select a color, d to remove the highlighted color, and B or Escape to go back)
+  - Show a color type in bold at the top of the color values: Press f (cycles through RGB, HSV, HSL, HWB, CMYK, hex, decimal, ansi, and no emphasis; the choice is saved in ~/.config/cpick/config.json)
+  - Name the highlighted color and save it to colors.json: Press s (type a name and press Enter to save the color or Escape to go back; the color is added to the "custom" color list, which is created if needed, and a colors.json with the preset colors is created in ~/.config/cpick if there is none)
+  - Show larger color blocks: Press L (press L again to go back; only on screens large enough for the wide color blocks)
 
 For everything:
 
   - Movement: Use the standard vim keys (hjkl) or arrow keys
   - Advanced movement: Press g to go to the top left of the table and press G to go to the bottom right of the table.
   - Exiting the application: Press q or Escape
+  - Mouse: Click or drag on the hue and saturation-value tables to move the selection, and click a preset color to create a new table based on it (turn the mouse off with -no-mouse)
 
 For hue screen (the first screen seen when cpick runs; it contains a slider at the top of the screen, and a list of colors at the bottom)
 
+On screens smaller than 80x24, the slider is scaled to fit the width of the screen and the list of colors is only shown while the preset color table is selected.
+
   - Creating a new table based on selection: Press Enter
   - Switch between slider and preset color table: Press Space
+  - Wrap around the ends of the slider: Start cpick with -wrap (pressing left at a hue of 0 goes to the last hue, and pressing right at the last hue goes to 0)
   - Switch between color types on preset color table: Press C to go forwards and c to go backwards (same as vim)
+  - Jump between color types on preset color table: Type a number before C or c to move that many pages (EX: 3C), and press < to go to the first page and > to go to the last page
+  - Sort the colors of the current preset color page: Press o (cycles between the default order, hue, lightness, and name)
+  - Filter the preset colors by name: Press / and type a name (the pages update as you type; press Enter to keep the filter and Escape to clear it)
   - Enter search menu (for preset colors): Press question mark (?)
   - Go to next search instance: Press N to go forwards and n to go backwards (same as vim)
   - Switch to saturation-value table: Press Tab
+  - Switch to the recently selected colors: Press p (press p again to switch back)
+  - Type a hex value into the field below the slider: Press # (the color values update as you type; press Enter to go to the saturation-value table at the typed color and Escape to go back)
+  - Jump to a hue on the hue table: Press : and type a hue between 0 and 359 (press Enter to move the hue table to the hue and Escape to go back)
+
+For the recently selected colors (a row of the last 10 selected colors on the hue screen, saved in ~/.config/cpick/history.json)
+
+  - Select a color: Press Enter
+  - Switch to saturation-value table: Press Tab
 
 For saturation-value screen (the second screen; it contains a large gradient of a single hue and the corresponding color values on the right)
 
-  - Select your final color: Press Enter
+  - Select your final color: Press Enter (in sandbox mode, the color is noted below the page and cpick keeps running)
+  - Change the alpha of the color: Press a to decrease and A to increase
+  - Change the value and saturation by a step: Press + and - to change the value and [ and ] to change the saturation (the exact hue, saturation, value, and hex value of the selected color are always shown below the color block, EX: H:  0 S:100 V: 90 and #e60000)
+  - The name of the closest preset color is always shown below the color values (EX: ≈ Firebrick (#b22222))
+  - Sample text is shown with the color on white, black, and gray backgrounds, and with those colors on the color, to check readability
+  - Switch between the top and bottom half of the selected cell: Press t (each cell shows two values using a half block, and the cursor only covers the half that is selected and returned)
+  - Undo and redo moving the selection (including changes of hue): Press u to undo and Ctrl-R to redo
+  - Switch to the shades and tints of the highlighted color: Press T (press Enter to select a shade or tint, and T or Tab to go back). The shades and tints are only shown on screens at least 40 lines tall
   - Switch to hue screen: Press Tab
 
 For the search menu (What opens when you press the question mark (?))
 
 	To search for a color name, type the name of the color into the search bar. Related colors will appear below.
 	Once a color (or phrase) is desired, press enter. You can press N (forward) and n (reverse) to swap between instances.
+	Names don't need to be exact, so small typos and missing spaces still match (EX: "ligth blue"), with the closest colors first.
+	Pasted text (EX: a hex value copied from elsewhere) is added to the search bar at once, with spaces and line breaks around it removed.
 
 	Each value type you want to select will have instructions below:
 
-		* Hexadecimal: type the hex value starting with "#" (EX: #ffffff)
+		* Hexadecimal: type the hex value, with or without the "#" (EX: #ffffff, ffffff, the shorthand fff, or #ffffffcc with an alpha). Text without the "#" that is also part of a color name, such as cad, searches for the name instead. The closest preset colors can then be cycled through with N and n.
 		* RGB: type "rgb:" and three RGB values separated by a space (EX: rgb: 255 255 255)
 		* HSV: type "hsv:" and three HSV values separated by a space (EX: hsv: 0 100 0)
 		* HSL: type "hsl:" and three HSL values separated by a space (EX: hsl: 0 100 50)
 		* CMYK: type "cmyk:" and four CMYK values separated by a space (EX: cmyk: 0 0 0 0)
 		* Decimal: type "decimal:" and then the decimal value (EX: 16777215)
+		* Color temperature: type "kelvin:" and then a temperature between 1000 and 40000 (EX: kelvin: 6500). The color comes from a curve fit of blackbody colors, so it is only an approximation: it is within a few values of each channel, is less accurate below 1900K and above 10000K, and doesn't account for the white point of the display.
+
+	To check the WCAG contrast ratio of colors against a background, type "bg:" and then a color value in any of
+	the formats above (EX: bg: #ffffff). The ratio and whether it passes AA (4.5:1) and AAA (7:1) will be shown on
+	the Saturation-Value table. Type "bg:" alone to hide it.
 
 	Once a color is selected, you will be taken to the Saturation-Value table with the specified color selected.
 
@@ -53,15 +105,22 @@ Return values
 	* RGB
 	* HSV
 	* HSL
+	* HWB
 	* CMYK
 	* Hex
 	* Decimal
 	* Ansi
 	* Name
+	* Alpha
 
 RGB, HSV, HSL, CMYK, Hex, Decimal, and Ansi all come from the colors package
 (github.com/ethanbaker/colors).
 
+HWB (hue, whiteness, and blackness) is converted from HSV by cpick, since it is
+not part of the colors package.
+
+Alpha is a value between 0 and 1 (inclusive) that defaults to 1.
+
 Name will only be returned if you select a value from the preset color table. Name
 will be "Custom color" if no preset color is selected.
 
@@ -69,6 +128,13 @@ A "Hello World" for cpick:
 
 An example to start cpick in "normal" mode: cpick.Start(false, false)
 
+A picker can also be created once and started any number of times, with every
+start beginning like the first one. Every picker has its own state, so pickers
+that are given their own screens can run at the same time:
+
+	picker := cpick.NewPicker(cpick.Config{Initial: "#3366ff"})
+	c, err := picker.Start()
+
 Command Usage:
 
 A cpick bash command can be installed by running `go install` in the cmd/cpick/ directory.
@@ -83,39 +149,103 @@ SYNOPSIS
 
 	cpick [TYPE] [OPTION]
 
+	cpick convert [COLOR] [TYPE] [OPTION]
+
+	cpick batch [FILE] [TYPE] [OPTION]
+
+	cpick export [-gpl FILE] [-ase FILE]
+
+	cpick gradient [START] [END] [STEPS] [TYPE] [OPTION]
+
 DESCRIPTION
 
 	Bring up an extensive color picker to select and return many different colors in
 	various color types.
 
+CONVERT
+
+	The convert subcommand converts a color to one of the types below without
+	starting the color picker. The color can be given in any of the formats accepted
+	by the search bar (EX: cpick convert "#ff8800" hsl). By default, the type is
+	ansi, or the type in CPICK_DEFAULT_TYPE.
+
+BATCH
+
+	The batch subcommand converts every color in a file, one color on each line, to
+	one of the types below without starting the color picker (EX: cpick batch
+	colors.txt hsl). Each converted color is printed on its own line and empty lines
+	are skipped. A line that can't be converted is reported on stderr with its line
+	number (EX: colors.txt:3: ...), and the rest of the file is still converted. If
+	FILE is "-", the colors are read from standard input. By default, the type is
+	ansi, or the type in CPICK_DEFAULT_TYPE.
+
+GRADIENT
+
+	The gradient subcommand prints a gradient of STEPS colors between two colors,
+	including both of them, without starting the color picker. The colors can be
+	given in any of the formats accepted by the search bar, and each color is printed
+	on its own line (EX: cpick gradient "#000000" "#ffffff" 5). By default, STEPS is 9
+	and the type is hex.
+
+EXPORT
+
+	The export subcommand writes every preset color to a palette file without
+	starting the color picker. Use -gpl to write a GIMP palette (EX: cpick export
+	-gpl mypalette.gpl) and -ase to write an Adobe Swatch Exchange file (EX: cpick
+	export -ase mypalette.ase). If the file is "-", the palette is written to
+	standard output.
+
 TYPES
 
-	Types: [rgb|hsv|hsl|cmyk|hex|decimal|ansi|escape|name|json|bash [NAME]|css [TAG]]
+	Types: [all|rgb|rgba|hsv|hsl|hwb|cmyk|hex|decimal|kelvin|ansi|escape|name|json|yaml|toml|csv|bash [NAME]|css [TAG]|scss [NAME]|swift|swiftui|plist [alpha]|android [NAME]|flutter|qt [FORM]|latex [NAME]|emacs [ATTRIBUTE]|vim [ATTRIBUTE]|rust [FORM] [TYPE]|colorref|powershell [alpha]|gl [alpha]|contrast [ratio]|color256|color16]
 
-	Default: ansi
+	Default: ansi (or the type in CPICK_DEFAULT_TYPE)
 
 	Multiple types will result in the first type entered selected to return. For
 	example, running `cpick rgb ansi` will return rgb values.
 
+	all: Return the rgb, hsv, hsl, hwb, cmyk, hex, decimal, ansi, and name types
+	on labeled lines (EX: rgb:     255;127;0, then hsv:     30;100;100, and so on)
+
 	rgb: Return rgb values separated by a semi-colon (EX: 255;127;0)
 
+	rgba: Return a css rgba function with the alpha of the color (EX: rgba(255,127,0,1.0))
+
 	hsv: Return hsv values separated by a semi-colon (EX: 60;100;100)
 
 	hsl: Return hsl values separated by a semi-colon (EX: 60;100;50)
 
+	hwb: Return a css hwb function with the hue, whiteness, and blackness (EX: hwb(30
+	0% 0%))
+
 	cmyk: Return cmyk values separated by a semi-colon (EX: 60;100;50)
 
-	hex: Return a hex value with the "#" (EX: #ffff00)
+	hex: Return a hex value with the "#", and the alpha if it isn't full (EX: #ffff00
+	or #ffff00cc)
 
 	decimal: Return a deciaml value (EX: 13842970)
 
+	kelvin: Return the nearest color temperature of the color, rounded to 100K (EX:
+	6500K). Only the balance of the channels is compared, and colors that aren't
+	close to a blackbody color (such as greens and purples) still get the nearest
+	temperature.
+
 	ansi: Return the value of an ansi escape code (this will be represented as a color)
 
 	escape: Return the ansi escape code (EX: \033[38;2;255;127;0m)
 
 	name: Return the name of the color (if there is one)
 
-	json: Return a json object containing all of the color info
+	json: Return a json object containing all of the color info. The keys are always
+	lowercase: rgb (r, g, b), hsv (h, s, v), hsl (h, s, l), hwb (h, w, b), cmyk (c, m,
+	y, k), hex, decimal, ansi, name, alpha, and colorIndex
+
+	yaml: Return a yaml document containing all of the color info (same keys as json)
+
+	toml: Return a toml document containing all of the color info (same keys as json)
+
+	csv: Return a csv header and row with the R, G, B, H, S, V, hex, decimal, and name of
+	the color. The header can be left off with -no-header.
 
 	css: Return a css line containing a certain tag with the specified color in
 	hexadecimal format. Css takes another keyword, [TAG], which is the specified css
@@ -124,5 +254,260 @@ TYPES
 	bash: Return a readonly statement with the color constant as an ansi escape code.
 	Bash takes another keyword, [NAME], that is used as the name of the declaration
 	statement. By default, [NAME]="custom".
+
+	scss: Return a SCSS variable declaration with the color in hexadecimal format.
+	Scss takes another keyword, [NAME], that is used as the name of the variable. By
+	default, [NAME]="custom".
+
+	swift: Return a UIColor initializer with values between 0 and 1 (EX: UIColor(red:
+	1.000, green: 0.498, blue: 0.000, alpha: 1.000))
+
+	swiftui: Return a SwiftUI Color initializer with values between 0 and 1 (EX:
+	Color(red: 1.000, green: 0.498, blue: 0.000))
+
+	plist: Return an XML property list dictionary for Apple property lists and asset
+	catalogs, with red, green, and blue keys between 0 and 1 in that order (EX:
+	<dict><key>red</key><real>1.000</real>...</dict>, written on separate lines with two
+	space indentation). If "alpha" is given, an alpha key is added after them.
+
+	android: Return an Android color resource with the color as an uppercase ARGB hex
+	value (EX: <color name="custom">#FFFF7F00</color>). Android takes another keyword,
+	[NAME], that is used as the name of the resource. By default, [NAME]="custom".
+
+	flutter: Return a Flutter Color with the color as an uppercase ARGB hex value (EX:
+	Color(0xFFFF7F00))
+
+	qt: Return a Qt stylesheet line with the color in hexadecimal format (EX: color:
+	#ff7f00;). Qt takes another keyword, [FORM], which can be "stylesheet" or "qcolor".
+	If [FORM]="qcolor", a QColor initializer is returned instead (EX: QColor(255, 127,
+	0)). By default, [FORM]="stylesheet".
+
+	latex: Return an xcolor definition with the color as an uppercase hex value (EX:
+	\definecolor{custom}{HTML}{FF7F00}). Latex takes another keyword, [NAME], that is
+	used as the name of the color. By default, [NAME]="custom".
+
+	emacs: Return an Emacs face attribute list with the color as a lowercase hex value
+	(EX: (:foreground "#ff7f00")). Emacs takes another keyword, [ATTRIBUTE], which can
+	be "foreground" or "background". By default, [ATTRIBUTE]="foreground".
+
+	vim: Return the arguments of a Vim highlight command with the color as a lowercase
+	hex value and the index of the closest color in the 256 color terminal palette (EX:
+	guifg=#ff7f00 ctermfg=208). Vim takes another keyword, [ATTRIBUTE], which can be
+	"foreground" or "background". If [ATTRIBUTE]="background", guibg and ctermbg are
+	used instead. By default, [ATTRIBUTE]="foreground".
+
+	rust: Return a Rust array literal with the RGB values of the color (EX: [255, 127,
+	0]). Rust takes two more keywords. [FORM] can be "array" or "tuple", and a tuple
+	literal is returned if [FORM]="tuple" (EX: (255, 127, 0)). [TYPE] can be "u8" or
+	"f32", and the values are normalized between 0 and 1 if [TYPE]="f32" (EX: [1.000,
+	0.498, 0.000]). By default, [FORM]="array" and [TYPE]="u8".
+
+	colorref: Return a Windows COLORREF value, which stores the color in blue, green,
+	red order (EX: 0x007FFF for #ff7f00).
+
+	powershell: Return a PowerShell System.Drawing.Color (EX:
+	[System.Drawing.Color]::FromArgb(255,127,0)). If "alpha" is given, the alpha of the
+	color between 0 and 255 is passed first.
+
+	gl: Return the RGB values normalized between 0 and 1 and separated by spaces (EX:
+	1.000 0.498 0.000). If "alpha" is given, the alpha is added as a fourth value.
+
+	contrast: Return the text color (black or white) with the highest WCAG contrast
+	ratio on the color (EX: #000000). If "ratio" is given, the contrast ratio is added
+	after the text color (EX: #000000 8.29). The saturation-value screen also shows the
+	best text color on the highlighted color.
+
+	color256: Return the index of the color in the 256 color terminal palette (EX: 208).
+	If the color was not picked from the palette, the closest color is used.
+
+	color16: Return the SGR code of one of the 16 standard terminal colors (EX: 31 for
+	red or 91 for bright red). If the color was not picked from the standard colors, the
+	closest standard color is used.
+
+OPTIONS
+
+	Options can be given before or after the arguments of a type.
+
+	-i, -initial [HEX]: Start cpick on the saturation-value table with the given
+	hex color selected (EX: -i "#3366ff", the shorthand -i "#36f", or -i "#3366ffcc" with
+	an alpha). If the hex value is invalid, an error is printed and cpick starts on the
+	hue table.
+
+	If no initial color is given and a color is piped to cpick, the first line of stdin
+	is used as the initial color (EX: echo "rgb: 51 102 255" | cpick hex). The color can
+	be in any format accepted by convert.
+
+	-no-restore: Start cpick on the hue table instead of at the last selected color. The
+	last selected color is saved in ~/.config/cpick/last.json.
+
+	-grayscale: Start cpick in grayscale mode, where the saturation-value table only
+	contains grays.
+
+	-cmyk: Start cpick on the CMYK page, where the color is adjusted with cyan, magenta,
+	yellow, and black sliders and shown as a printed swatch. The selected color keeps the
+	exact CMYK values.
+
+	-list: Start cpick on an alphabetical list of every named preset color, where a
+	color is picked without the table layout.
+
+	-palette [NAME]: Only show the preset colors of a palette file (EX: -palette work
+	for ~/.config/cpick/palettes/work.json).
+
+	-simulate [TYPE]: Start cpick simulating the given type of color blindness
+	(protanopia, deuteranopia, or tritanopia). The simulation only changes how
+	colors are drawn, not the returned color.
+
+	-color-mode [MODE]: Draw colors in truecolor (the default) or as the nearest of the
+	256 terminal colors (256), for terminals that don't draw truecolor properly. The mode
+	only changes how colors are drawn, not the returned color. If the terminal doesn't
+	advertise truecolor through COLORTERM or its terminfo entry, cpick suggests the 256
+	color mode on the status line when it starts.
+
+	-format [TEMPLATE]: Print the color using a Go text/template instead of the type
+	(EX: -format '{{.Hex}} rgb({{.RGB.R}},{{.RGB.G}},{{.RGB.B}})'). The available
+	fields are RGB (R, G, B), HSV (H, S, V), HSL (H, S, L), HWB (H, W, B), CMYK (C, M, Y, K), Hex,
+	Decimal, Ansi, Name, Alpha, and ColorIndex.
+
+	-preview-large: Start cpick with larger color blocks, which can also be toggled with L.
+
+	-no-mouse: Turn off mouse support, so that the terminal handles mouse clicks as usual.
+
+	-wrap: Wrap the selection of the hue table around its ends, so that pressing left at
+	a hue of 0 goes to the last hue and pressing right at the last hue goes to 0.
+
+	-no-presets: Leave the preset color panel out of the hue screen, giving the color
+	blocks more room and starting faster. Colors can't be searched by name without it.
+
+	-step [N]: The amount that + and - change the value and [ and ] change the saturation
+	on the saturation-value table. By default, the step is 5.
+
+	-sv-rows [N]: The number of rows of the saturation-value table, between 10 and 100.
+	Each row shows two values, so more rows give finer control over the value (with 100
+	rows, j and k change the value by 1). The rows are limited to the height of the
+	screen. By default, there are 50 rows.
+
+	-single-tint: Show only the color that is selected instead of the dark and light
+	tints of the highlighted cell. Each cell of the saturation-value table shows two
+	values using a half block, and the light tint (the top half) is the one that is
+	selected.
+
+	-theme [NAME]: Restyle cpick for the terminal. The dark theme is the default, light
+	uses dark text on a white background for terminals with a light background, and mono
+	only uses black, white, and grays so that the picked colors are the only colors on
+	the screen.
+
+	-sandbox: Browse colors without stopping cpick. Pressing enter on the saturation-value
+	table notes the color below the page and adds it to the recently selected colors.
+	If -output is given, every noted color is written to the file in the given type
+	(EX: cpick hex -sandbox -output noted.txt -append). Quitting cpick in sandbox mode
+	exits successfully.
+
+	-count [N]: Select N colors before cpick stops. After each selection, cpick goes
+	back to the hue screen, and each color is printed on its own line.
+
+	-random: Print a random color in the given type without starting cpick (EX: cpick
+	hex -random). With -count, that many random colors are printed, each on its own line.
+
+	-generate-names: Name colors that aren't preset colors after the closest preset color
+	and their hex value (EX: darkorange-ff8800) instead of "custom color".
+
+	-no-header: Leave off the header of the csv type, so that rows can be added to an
+	existing file (EX: cpick csv -no-header >> colors.csv).
+
+	-compact: Print the json type on a single line instead of indenting it, which is
+	useful when the output is embedded in a larger json object.
+
+	-d, -delimiter [DELIMITER]: Separate the values of the rgb, hsv, hsl, cmyk, and gl
+	types with the given delimiter instead of a semi-colon, or a space for gl (EX: -d ,
+	prints 255,127,0).
+
+	-o, -output [FILE]: Write the output to a file instead of stdout. An existing file is
+	overwritten unless -append is also given.
+
+	-append: Add the output to the end of the -output file instead of overwriting it.
+
+	-n, -no-newline: Leave off the trailing newline of the output, which is useful when
+	capturing the output in a shell variable. When more than one color is printed
+	(EX: with -count), only the newline after the last color is left off.
+
+	-u, -uppercase: Print hex values in uppercase (EX: #FF7F00). This applies to every
+	type that contains a hex value, such as hex, css, scss, and json.
+
+	-clipboard: Copy the output to the system clipboard in addition to printing it.
+	This requires one of pbcopy, clip.exe, wl-copy, xclip, xsel, or
+	termux-clipboard-set to be installed.
+
+	-v, -version: Print the version of cpick, along with the module version and git
+	revision it was built from when they are available, and exit without starting cpick.
+
+EXIT STATUS
+
+	cpick exits with 0 once a color is selected and with 1 if an error occurs. If
+	the application is quit without selecting a color, nothing is printed and cpick
+	exits with 2.
+
+ENVIRONMENT
+
+	CPICK_DEFAULT_TYPE: The type used when no type is given (EX: CPICK_DEFAULT_TYPE=hex
+	cpick prints a hex value). A type given on the command line is always used instead.
+	An invalid type is reported on stderr and ansi is used.
+
+CONFIG
+
+	Extra palettes can be added as JSON files in ~/.config/cpick/palettes, using the
+	same format as colors.json. Each color list of a palette file is shown as its own
+	group of pages after the other preset colors.
+
+	Settings are read from ~/.config/cpick/config.json. The "keybindings" section
+	maps actions to a key or a list of keys. Keys are written as the character
+	itself (EX: "q") or as the name of the key (EX: "Esc", "Tab", "Ctrl-F"). Any
+	action that is not given keeps its default keys. The help screen always shows
+	the keys that are currently bound. The actions and their default keys are:
+
+		quit             q or Esc         search           Ctrl-F or ?
+		help             `                switch-table     Tab
+		copy             y                copy-escape      Y
+		history          p                harmonies        H
+		simulate         V                grayscale        w
+		random           r                color-mode       M
+		undo             u                redo             Ctrl-R
+		reload           F5               ramp             T
+		large-preview    L                emphasis         f
+		palette-256      X                palette-16       x
+		sliders          S                gradient         e
+		cmyk             K                named-list       I
+		add-favorite     b                favorites        B
+		save             s                hex-input        #
+		hue-input        :                switch-presets   " " (space)
+		sv-half          t                alpha-down       a
+		alpha-up         A                value-up         +
+		value-down       -                saturation-up    ]
+		saturation-down  [                next-page        C
+		prev-page        c                first-page       <
+		last-page        >                sort             o
+		filter           /                next-match       N
+		prev-match       n                gradient-more    +
+		gradient-fewer   -                remove-favorite  d
+
+	The "theme" setting is the theme used when
+	-theme is not given, and the "emphasis" setting is the color type shown in bold
+	at the top of the color values (saved when it is changed with f). If the
+	"cacheColors" setting is true, the parsed colors of colors.json and of the palette
+	files are kept in ~/.config/cpick/cache, which speeds up starting with large color
+	files. Each file has its own cache, which is made again whenever the file changes.
+
+		{
+			"theme": "light",
+			"emphasis": "hex",
+			"cacheColors": true,
+			"keybindings": {
+				"quit": ["Ctrl-Q"],
+				"help": "`",
+				"search": ["Ctrl-F", "?"],
+				"next-page": "C",
+				"prev-page": "c",
+				"switch-table": ["Tab"]
+			}
+		}
 */
 package cpick
@@ -0,0 +1,39 @@
+package cpick
+
+// The color types that can be emphasized at the top of the color values, in
+// the order of the color values
+var emphasisNames = []string{"none", "rgb", "hsv", "hsl", "hwb", "cmyk", "hex", "decimal", "ansi"}
+
+// Get the index of an emphasized color type, or 0 if there is no type with the
+// name
+func getEmphasis(name string) int {
+	for i, v := range emphasisNames {
+		if v == name {
+			return i
+		}
+	}
+
+	return 0
+}
+
+// Cycle through the color types that are emphasized, redraw the color values,
+// and remember the type in the config file
+func (p *Picker) cycleEmphasis() {
+	p.emphasis = (p.emphasis + 1) % len(emphasisNames)
+
+	// Redraw the color values of the focused page
+	switch {
+	case p.svTable.HasFocus():
+		p.svTableSelectionChangedFunc(p.svTable.GetSelection())
+	case p.hTable.HasFocus():
+		p.hTableSelectionChangedFunc(p.hTable.GetSelection())
+	case p.colorPages.HasFocus():
+		p.colorPageSelectionChangedFunc(p.colorInfo[p.colorPageIndex].table.GetSelection())
+	case p.sliderForm.HasFocus():
+		p.sliderChangedFunc(0)
+	case p.cmykForm.HasFocus():
+		p.cmykChangedFunc(0)
+	}
+
+	saveConfigSetting("emphasis", emphasisNames[p.emphasis])
+}
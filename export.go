@@ -0,0 +1,98 @@
+package cpick
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"unicode/utf16"
+
+	color "github.com/ethanbaker/colors"
+)
+
+// Get the lists of preset colors without setting up the color pages
+func getColorLists() ([]jsonColorType, error) {
+	data, err := getCustomColors("")
+	return data.COLORLIST, err
+}
+
+// ExportGPL function writes every preset color to w as a GIMP palette (.gpl)
+func ExportGPL(w io.Writer) error {
+	lists, err := getColorLists()
+	if err != nil {
+		return err
+	}
+
+	return writeGPL(w, lists)
+}
+
+// Write lists of colors in the GIMP palette format, with a comment before the
+// colors of each list
+func writeGPL(w io.Writer, lists []jsonColorType) error {
+	if _, err := fmt.Fprint(w, "GIMP Palette\nName: cpick\nColumns: 9\n#\n"); err != nil {
+		return err
+	}
+
+	for _, list := range lists {
+		if _, err := fmt.Fprintf(w, "# %v\n", list.NAME); err != nil {
+			return err
+		}
+
+		for _, c := range list.COLORS {
+			rgb := color.HextoRGB(color.Hex(c.VALUE))
+			if _, err := fmt.Fprintf(w, "%3d %3d %3d\t%v\n", rgb.R, rgb.G, rgb.B, c.NAME); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// ExportASE function writes every preset color to w as an Adobe Swatch
+// Exchange (.ase) file
+func ExportASE(w io.Writer) error {
+	lists, err := getColorLists()
+	if err != nil {
+		return err
+	}
+
+	var colors []jsonColor
+	for _, list := range lists {
+		colors = append(colors, list.COLORS...)
+	}
+
+	return writeASE(w, colors)
+}
+
+// Write a list of colors in the ASE format. Every field is big endian.
+func writeASE(w io.Writer, colors []jsonColor) error {
+	// Header: signature, version 1.0, and the number of blocks
+	header := []interface{}{[]byte("ASEF"), uint16(1), uint16(0), uint32(len(colors))}
+	for _, v := range header {
+		if err := binary.Write(w, binary.BigEndian, v); err != nil {
+			return err
+		}
+	}
+
+	for _, c := range colors {
+		rgb := color.HextoRGB(color.Hex(c.VALUE))
+		name := utf16.Encode([]rune(c.NAME + "\x00"))
+
+		// Name length, name, color model, three floats, and color type
+		length := 2 + len(name)*2 + 4 + 12 + 2
+
+		block := []interface{}{
+			uint16(0x0001), uint32(length),
+			uint16(len(name)), name,
+			[]byte("RGB "), float32(rgb.R) / 255, float32(rgb.G) / 255, float32(rgb.B) / 255,
+			uint16(2),
+		}
+		for _, v := range block {
+			if err := binary.Write(w, binary.BigEndian, v); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,208 @@
+package cpick
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	color "github.com/ethanbaker/colors"
+	"github.com/ethanbaker/cpick/cview"
+	"github.com/gdamore/tcell/v2"
+)
+
+// Favorites Handlers -----------------------------------------------------
+
+func (p *Picker) favoritesDoneFunc(key tcell.Key) {
+	if key == tcell.KeyEscape {
+		p.hideFavorites()
+	}
+}
+
+func (p *Picker) favoritesSelectedFunc(row int, column int) {
+	if row >= len(p.favorites) {
+		return
+	}
+
+	hsv := color.HextoHSV(color.Hex(p.favorites[row]))
+	p.selectColor(hsv, hsv)
+}
+
+// Remove the highlighted favorite
+func (p *Picker) favoritesCaptureHandler(event *tcell.EventKey) *tcell.EventKey {
+	if p.eventMatches("remove-favorite", event) {
+		row, _ := p.favoritesTable.GetSelection()
+		p.removeFavorite(row)
+		return nil
+	}
+
+	return event
+}
+
+// Show the favorite colors, or go back if they are already shown
+func (p *Picker) toggleFavorites() {
+	if p.favoritesTable.HasFocus() {
+		p.hideFavorites()
+		return
+	}
+
+	p.favoritesReturnPage, _ = p.pages.GetFrontPage()
+	p.favoritesFocus = p.app.GetFocus()
+
+	p.drawFavoritesTable()
+	p.favoritesTable.Select(0, 0)
+	p.pages.SwitchToPage("Favorites page")
+	p.app.SetFocus(p.favoritesTable)
+}
+
+func (p *Picker) hideFavorites() {
+	p.pages.SwitchToPage(p.favoritesReturnPage)
+	p.app.SetFocus(p.favoritesFocus)
+}
+
+// Favorites setup --------------------------------------------------------
+
+func (p *Picker) favoritesSetup() {
+	p.favorites = loadFavorites()
+
+	p.favoritesTable.SetSelectable(true, false)
+	p.favoritesTable.SetScrollBarVisibility(cview.ScrollBarAuto)
+	p.favoritesTable.SetSelectedStyle(tcell.ColorDefault, tcell.ColorGray, tcell.AttrNone)
+	p.favoritesTable.SetDoneFunc(p.favoritesDoneFunc)
+	p.favoritesTable.SetSelectedFunc(p.favoritesSelectedFunc)
+	p.favoritesTable.SetInputCapture(p.favoritesCaptureHandler)
+
+	title := cview.NewTextView()
+	title.SetTextAlign(cview.AlignCenter)
+	title.SetText("Favorite colors (saved in ~/.config/cpick/favorites.json)")
+
+	help := cview.NewTextView()
+	help.SetTextAlign(cview.AlignRight)
+	help.SetText("Press enter to select a color, d to remove a color, and B or escape to go back")
+
+	p.favoritesFlex.SetDirection(cview.FlexRow)
+	p.favoritesFlex.AddItem(title, 1, 0, false)
+	p.favoritesFlex.AddItem(p.favoritesTable, 0, 1, true)
+	p.favoritesFlex.AddItem(help, 1, 0, false)
+
+	p.drawFavoritesTable()
+}
+
+func (p *Picker) drawFavoritesTable() {
+	p.favoritesTable.Clear()
+
+	if len(p.favorites) == 0 {
+		cell := cview.NewTableCell("  No favorite colors yet, press b on a color to add it")
+		cell.SetSelectable(false)
+		p.favoritesTable.SetCell(0, 0, cell)
+		return
+	}
+
+	for row, hex := range p.favorites {
+		hsv := color.HextoHSV(color.Hex(hex))
+		rgb := color.HSVtoRGB(hsv)
+
+		text := "  " + fmt.Sprintf(colorPageText, p.getColorName(hsv, hsv), "#"+hex)
+		if tag := p.readableTag(rgb); tag != "" {
+			text = "  " + tag + fmt.Sprintf(colorPageText, p.getColorName(hsv, hsv), "#"+hex)
+		}
+
+		cell := cview.NewTableCell(text)
+		cell.SetTextColor(p.displayColor(rgb))
+		p.favoritesTable.SetCell(row, 0, cell)
+	}
+
+	// Keep the selection on the table when a color is removed
+	if row, _ := p.favoritesTable.GetSelection(); row >= len(p.favorites) {
+		p.favoritesTable.Select(len(p.favorites)-1, 0)
+	}
+}
+
+// Helper functions -------------------------------------------------------
+
+// Add the highlighted color to the favorites, unless it is already one
+func (p *Picker) addFavorite() {
+	hsv, ok := p.getCurrentColor()
+	if !ok {
+		return
+	}
+
+	hex := strings.ToLower(string(color.HSVtoHex(hsv)))
+	for _, v := range p.favorites {
+		if v == hex {
+			return
+		}
+	}
+
+	p.favorites = append(p.favorites, hex)
+	p.drawFavoritesTable()
+
+	p.storeFavorites()
+}
+
+// Remove the favorite at an index
+func (p *Picker) removeFavorite(index int) {
+	if index < 0 || index >= len(p.favorites) {
+		return
+	}
+
+	p.favorites = append(p.favorites[:index], p.favorites[index+1:]...)
+	p.drawFavoritesTable()
+
+	p.storeFavorites()
+}
+
+// Load the favorites file. A missing or invalid file results in no favorites.
+func loadFavorites() []string {
+	path, err := configPath("favorites.json")
+	if err != nil {
+		return nil
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+
+	var colors []string
+	if err := json.Unmarshal(raw, &colors); err != nil {
+		return nil
+	}
+
+	// Only keep valid hex values
+	var valid []string
+	for _, v := range colors {
+		if validHex(v) {
+			valid = append(valid, strings.ToLower(v))
+		}
+	}
+
+	return valid
+}
+
+// Save the favorites and show an error if they couldn't be saved
+func (p *Picker) storeFavorites() {
+	if err := p.saveFavorites(); err != nil {
+		p.setStatus(fmt.Sprintf("Could not save the favorites: %v", err), true)
+	}
+}
+
+// Save the favorites to the favorites file
+func (p *Picker) saveFavorites() error {
+	path, err := configPath("favorites.json")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	raw, err := json.MarshalIndent(p.favorites, "", "    ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, raw, 0644)
+}
@@ -0,0 +1,68 @@
+package cpick
+
+import (
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// Filter Input Handlers --------------------------------------------------
+
+func (p *Picker) filterInputChangedFunc(text string) {
+	p.colorFilter = strings.ToLower(strings.TrimSpace(text))
+
+	// The positions of the colors change, so old search results are invalid
+	p.searchIndexes = nil
+
+	for i := range p.colorInfo {
+		p.drawColorTable(i)
+		p.colorInfo[i].table.Select(0, 0)
+	}
+}
+
+func (p *Picker) filterInputDoneFunc(key tcell.Key) {
+	switch key {
+	// Clear the filter and go back to the preset colors
+	case tcell.KeyEscape:
+		p.filterInput.SetText("")
+		p.focusColorPages()
+
+	// Keep the filter and go back to the preset colors
+	case tcell.KeyEnter:
+		p.focusColorPages()
+	}
+}
+
+// Only keep the colors whose names contain the filter
+func filterColors(colors []jsonColor, filter string) []jsonColor {
+	if filter == "" {
+		return colors
+	}
+
+	var filtered []jsonColor
+	for _, c := range colors {
+		if strings.Contains(strings.ToLower(c.NAME), filter) {
+			filtered = append(filtered, c)
+		}
+	}
+
+	return filtered
+}
+
+// Move the focus from the filter to the preset colors
+func (p *Picker) focusColorPages() {
+	p.hFocus = p.colorPages
+	p.app.SetFocus(p.colorPages)
+
+	p.colorInfo[p.colorPageIndex].table.SetSelectedStyle(tcell.ColorDefault, tcell.ColorGray, tcell.AttrNone)
+	p.colorPageSelectionChangedFunc(p.colorInfo[p.colorPageIndex].table.GetSelection())
+}
+
+// Filter Input setup -----------------------------------------------------
+
+func (p *Picker) filterInputSetup() {
+	p.filterInput.SetText("")
+	p.filterInput.SetLabel("Filter: ")
+	p.filterInput.SetChangedFunc(p.filterInputChangedFunc)
+	p.filterInput.SetDoneFunc(p.filterInputDoneFunc)
+}
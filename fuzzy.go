@@ -0,0 +1,97 @@
+package cpick
+
+import (
+	"sort"
+	"strings"
+)
+
+// Scores added to fuzzy matches so that substring matches always rank above
+// subsequence matches, which always rank above matches with typos
+const FUZZY_SUBSEQUENCE = 1000
+const FUZZY_TYPO = 2000
+
+// Score how well a search query matches a color name, where a lower score is
+// a better match. Spaces and case are ignored, so "lightblu" matches "Light
+// Blue". The query is matched as a substring, then as a subsequence, and then
+// with a small amount of typos.
+func fuzzyScore(query string, name string) (int, bool) {
+	q := strings.ReplaceAll(strings.ToLower(query), " ", "")
+	n := strings.ReplaceAll(strings.ToLower(name), " ", "")
+	if q == "" || n == "" {
+		return 0, false
+	}
+
+	// Substring matches closer to the start of the name are better
+	if i := strings.Index(n, q); i >= 0 {
+		return i, true
+	}
+
+	// Subsequence matches with fewer skipped characters are better
+	skipped, j := 0, 0
+	for i := 0; i < len(n) && j < len(q); i++ {
+		if n[i] == q[j] {
+			j++
+		} else if j > 0 {
+			skipped++
+		}
+	}
+	if j == len(q) {
+		return FUZZY_SUBSEQUENCE + skipped, true
+	}
+
+	// Allow about one typo for every four characters
+	if d := levenshtein(q, n); d <= len(q)/4 {
+		return FUZZY_TYPO + d, true
+	}
+
+	return 0, false
+}
+
+// Get the amount of insertions, deletions, and substitutions needed to turn
+// one string into another
+func levenshtein(a string, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			curr[j] = prev[j] + 1
+			if curr[j-1]+1 < curr[j] {
+				curr[j] = curr[j-1] + 1
+			}
+			if prev[j-1]+cost < curr[j] {
+				curr[j] = prev[j-1] + cost
+			}
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(b)]
+}
+
+// fuzzyMatch type used to rank a search result
+type fuzzyMatch struct {
+	name  string
+	score int
+	index int
+}
+
+// Sort matches so the best scores come first, putting shorter names first
+// when the scores are the same
+func sortMatches(matches []fuzzyMatch) {
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].score != matches[j].score {
+			return matches[i].score < matches[j].score
+		}
+		return len(matches[i].name) < len(matches[j].name)
+	})
+}
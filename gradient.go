@@ -0,0 +1,177 @@
+package cpick
+
+import (
+	"fmt"
+	"math"
+
+	color "github.com/ethanbaker/colors"
+	"github.com/ethanbaker/cpick/cview"
+	"github.com/gdamore/tcell/v2"
+)
+
+// Default number of colors in a gradient, including both endpoints
+const GRADIENT_STEPS = 9
+
+// Limits of the number of colors in a gradient shown in the application
+const (
+	GRADIENT_MIN_STEPS = 2
+	GRADIENT_MAX_STEPS = 32
+)
+
+// Gradient creates a gradient of steps colors between a and b, including
+// both of them. The colors are evenly spaced in RGB space, and so is the
+// alpha. If steps is 1, only a is returned.
+func Gradient(a, b ColorValues, steps int) []ColorValues {
+	if steps < 1 {
+		return nil
+	}
+
+	presets := filePresetColors("")
+	gradient := make([]ColorValues, steps)
+	for i := range gradient {
+		t := 0.0
+		if steps > 1 {
+			t = float64(i) / float64(steps-1)
+		}
+
+		lerp := func(x, y int) int {
+			return int(math.Round(float64(x) + float64(y-x)*t))
+		}
+
+		c := newRGBColorValues(color.RGB{R: lerp(a.RGB.R, b.RGB.R), G: lerp(a.RGB.G, b.RGB.G), B: lerp(a.RGB.B, b.RGB.B)}, presets)
+		c.Alpha = math.Round((a.Alpha+(b.Alpha-a.Alpha)*t)*100) / 100
+		gradient[i] = c
+	}
+
+	return gradient
+}
+
+// Gradient Handlers ------------------------------------------------------
+
+func (p *Picker) gradientDoneFunc(key tcell.Key) {
+	if key == tcell.KeyEscape {
+		p.hideGradient()
+	}
+}
+
+func (p *Picker) gradientSelectedFunc(row int, column int) {
+	c, ok := p.gradientTable.GetCell(row, column).GetReference().(ColorValues)
+	if !ok {
+		return
+	}
+
+	p.selectColorValues(c)
+}
+
+// Change the number of colors in the gradient
+func (p *Picker) gradientCaptureHandler(event *tcell.EventKey) *tcell.EventKey {
+	switch {
+	case p.eventMatches("gradient-more", event):
+		if p.gradientSteps < GRADIENT_MAX_STEPS {
+			p.gradientSteps++
+			p.drawGradientTable()
+		}
+		return nil
+
+	case p.eventMatches("gradient-fewer", event):
+		if p.gradientSteps > GRADIENT_MIN_STEPS {
+			p.gradientSteps--
+			p.drawGradientTable()
+		}
+		return nil
+	}
+
+	return event
+}
+
+// Set the highlighted color as the start of the gradient, or as the end if
+// the start is already set and show the gradient. If the gradient is already
+// shown, go back.
+func (p *Picker) toggleGradient() {
+	if p.gradientTable.HasFocus() {
+		p.hideGradient()
+		return
+	}
+
+	hsv, ok := p.getCurrentColor()
+	if !ok {
+		return
+	}
+
+	if !p.hasGradientStart {
+		p.gradientStart = hsv
+		p.hasGradientStart = true
+		p.gradientText.SetText(fmt.Sprintf("Gradient from #%v, press e on the end color", color.HSVtoHex(hsv)))
+		return
+	}
+
+	p.gradientEnd = hsv
+	p.clearGradientStart()
+
+	p.gradientReturnPage, _ = p.pages.GetFrontPage()
+	p.gradientFocus = p.app.GetFocus()
+
+	p.drawGradientTable()
+	p.gradientTable.Select(0, 0)
+	p.pages.SwitchToPage("Gradient page")
+	p.app.SetFocus(p.gradientTable)
+}
+
+func (p *Picker) hideGradient() {
+	p.pages.SwitchToPage(p.gradientReturnPage)
+	p.app.SetFocus(p.gradientFocus)
+}
+
+// Remove the start of the gradient so that the next gradient starts over
+func (p *Picker) clearGradientStart() {
+	p.hasGradientStart = false
+	p.gradientText.SetText("")
+}
+
+// Gradient setup ---------------------------------------------------------
+
+func (p *Picker) gradientSetup() {
+	p.gradientTable.SetSelectable(true, false)
+	p.gradientTable.SetScrollBarVisibility(cview.ScrollBarAuto)
+	p.gradientTable.SetSelectedStyle(tcell.ColorDefault, tcell.ColorGray, tcell.AttrNone)
+	p.gradientTable.SetDoneFunc(p.gradientDoneFunc)
+	p.gradientTable.SetSelectedFunc(p.gradientSelectedFunc)
+	p.gradientTable.SetInputCapture(p.gradientCaptureHandler)
+
+	p.gradientTitle.SetTextAlign(cview.AlignCenter)
+
+	help := cview.NewTextView()
+	help.SetTextAlign(cview.AlignRight)
+	help.SetText("Press enter to select a color, + and - to change the number of colors, and e or escape to go back")
+
+	p.gradientFlex.SetDirection(cview.FlexRow)
+	p.gradientFlex.AddItem(p.gradientTitle, 1, 0, false)
+	p.gradientFlex.AddItem(p.gradientTable, 0, 1, true)
+	p.gradientFlex.AddItem(help, 1, 0, false)
+}
+
+func (p *Picker) drawGradientTable() {
+	p.gradientTable.Clear()
+
+	start := newColorValues(p.gradientStart, "", p.alpha)
+	end := newColorValues(p.gradientEnd, "", p.alpha)
+
+	p.gradientTitle.SetText(fmt.Sprintf("Gradient of %v colors from #%v to #%v", p.gradientSteps, start.Hex, end.Hex))
+
+	for row, c := range Gradient(start, end, p.gradientSteps) {
+		text := fmt.Sprintf("  ██████████  #%v  ", c.Hex)
+		if tag := p.readableTag(c.RGB); tag != "" {
+			text = fmt.Sprintf("  ██████████  %v#%v  ", tag, c.Hex)
+		}
+
+		cell := cview.NewTableCell(text)
+		cell.SetTextColor(p.displayColor(c.RGB))
+		cell.SetReference(c)
+		p.gradientTable.SetCell(row, 0, cell)
+	}
+
+	// Keep the selection on the table when colors are removed
+	if row, _ := p.gradientTable.GetSelection(); row >= p.gradientSteps {
+		p.gradientTable.Select(p.gradientSteps-1, 0)
+	}
+}
@@ -0,0 +1,29 @@
+package cpick
+
+import (
+	color "github.com/ethanbaker/colors"
+)
+
+// Get the color of a position on the saturation-value table. In grayscale
+// mode, every color has a saturation of 0 so R=G=B.
+func (p *Picker) svColor(s int, v int) color.HSV {
+	if p.grayscale {
+		return color.HSV{H: 0, S: 0, V: v}
+	}
+
+	return color.HSV{H: p.hue, S: s, V: v}
+}
+
+// Turn grayscale mode on or off and redraw the saturation-value table
+func (p *Picker) setGrayscale(on bool) {
+	p.grayscale = on
+	p.setSVCursor()
+
+	if p.grayscale {
+		p.pages.SwitchToPage("Saturation-Value page")
+		p.app.SetFocus(p.svTable)
+	}
+
+	p.drawSVTable()
+	p.svTableSelectionChangedFunc(p.svTable.GetSelection())
+}
@@ -0,0 +1,108 @@
+package cpick
+
+import (
+	"fmt"
+
+	color "github.com/ethanbaker/colors"
+	"github.com/ethanbaker/cpick/cview"
+	"github.com/gdamore/tcell/v2"
+)
+
+// Names of the harmonies and the hue offsets of the colors in them
+var harmonyNames = [...]string{"Complementary", "Triadic", "Analogous"}
+var harmonyOffsets = [...][]int{{0, 180}, {0, 120, 240}, {330, 0, 30}}
+
+// Harmony Handlers -------------------------------------------------------
+
+func (p *Picker) harmonyDoneFunc(key tcell.Key) {
+	if key == tcell.KeyEscape {
+		p.hideHarmonies()
+	}
+}
+
+func (p *Picker) harmonySelectedFunc(row int, column int) {
+	hsv, ok := p.harmonyTable.GetCell(row, column).GetReference().(color.HSV)
+	if !ok {
+		return
+	}
+
+	p.selectSVColor(hsv)
+}
+
+// Show the harmonies of the highlighted color, or go back if they are
+// already shown
+func (p *Picker) toggleHarmonies() {
+	if p.harmonyTable.HasFocus() {
+		p.hideHarmonies()
+		return
+	}
+
+	hsv, ok := p.getCurrentColor()
+	if !ok {
+		return
+	}
+
+	p.harmonyReturnPage, _ = p.pages.GetFrontPage()
+	p.harmonyFocus = p.app.GetFocus()
+
+	p.drawHarmonyTable(hsv)
+	p.pages.SwitchToPage("Harmony page")
+	p.app.SetFocus(p.harmonyTable)
+}
+
+func (p *Picker) hideHarmonies() {
+	p.pages.SwitchToPage(p.harmonyReturnPage)
+	p.app.SetFocus(p.harmonyFocus)
+}
+
+// Harmony setup ----------------------------------------------------------
+
+func (p *Picker) harmonySetup() {
+	p.harmonyTable.SetSelectable(true, true)
+	p.harmonyTable.SetCellPadding(3, 1)
+	p.harmonyTable.SetScrollBarVisibility(cview.ScrollBarNever)
+	p.harmonyTable.SetSelectedStyle(tcell.ColorDefault, tcell.ColorGray, tcell.AttrNone)
+	p.harmonyTable.SetDoneFunc(p.harmonyDoneFunc)
+	p.harmonyTable.SetSelectedFunc(p.harmonySelectedFunc)
+
+	p.harmonyTitle.SetTextAlign(cview.AlignCenter)
+
+	help := cview.NewTextView()
+	help.SetTextAlign(cview.AlignRight)
+	help.SetText("Press enter to select a color and H or escape to go back")
+
+	p.harmonyFlex.SetDirection(cview.FlexRow)
+	p.harmonyFlex.AddItem(p.harmonyTitle, 0, 1, false)
+	p.harmonyFlex.AddItem(p.harmonyTable, 0, 8, true)
+	p.harmonyFlex.AddItem(help, 0, 1, false)
+}
+
+func (p *Picker) drawHarmonyTable(hsv color.HSV) {
+	p.harmonyTable.Clear()
+	p.harmonyTitle.SetText(fmt.Sprintf("Color harmonies of #%v", color.HSVtoHex(hsv)))
+
+	for row, offsets := range harmonyOffsets {
+		label := cview.NewTableCell(harmonyNames[row])
+		label.SetSelectable(false)
+		p.harmonyTable.SetCell(row, 0, label)
+
+		for i, offset := range offsets {
+			h := hsv
+			h.H = (hsv.H + offset) % 360
+			hex := color.HSVtoHex(h)
+			rgb := color.HSVtoRGB(h)
+
+			text := fmt.Sprintf(colorPageText, "", "#"+hex)
+			if tag := p.readableTag(rgb); tag != "" {
+				text = fmt.Sprintf("██████████  %v#%v  ", tag, hex)
+			}
+
+			cell := cview.NewTableCell(text)
+			cell.SetTextColor(p.displayColor(rgb))
+			cell.SetReference(h)
+			p.harmonyTable.SetCell(row, i+1, cell)
+		}
+	}
+
+	p.harmonyTable.Select(0, 1)
+}
@@ -0,0 +1,72 @@
+package cpick
+
+import (
+	"strings"
+
+	color "github.com/ethanbaker/colors"
+	"github.com/gdamore/tcell/v2"
+)
+
+// Hex Input Handlers -----------------------------------------------------
+
+func (p *Picker) hexInputChangedFunc(text string) {
+	if text == "" {
+		p.hexInput.ResetFieldNote()
+		return
+	}
+
+	rgb, err := parseHexInput(text)
+	if err != nil {
+		p.hexInput.SetFieldNote(err.Error())
+		return
+	}
+	p.hexInput.ResetFieldNote()
+
+	// Show the typed color on the hue screen
+	hsv := color.RGBtoHSV(rgb)
+	p.setColorValues(hsv, p.darkHBlock, p.darkHText, hsv, p.lightHBlock, p.lightHText)
+}
+
+func (p *Picker) hexInputDoneFunc(key tcell.Key) {
+	switch key {
+	// Go back to the hue screen without selecting a color
+	case tcell.KeyEscape:
+		p.hexInput.SetText("")
+		p.hexInput.ResetFieldNote()
+		p.app.SetFocus(p.hFocus)
+
+	// Go to the saturation-value table at the typed color
+	case tcell.KeyEnter:
+		rgb, err := parseHexInput(p.hexInput.GetText())
+		if err != nil {
+			p.hexInput.SetFieldNote(err.Error())
+			return
+		}
+
+		p.hexInput.SetText("")
+		p.hexInput.ResetFieldNote()
+		p.selectSVColor(color.RGBtoHSV(rgb))
+	}
+}
+
+// Parse the text of the hex input using the same validation as the search
+// bar. The leading "#" is optional.
+func parseHexInput(text string) (color.RGB, error) {
+	return parseColor("#" + strings.TrimPrefix(strings.TrimSpace(text), "#"))
+}
+
+// Check if the user is typing into a text field, so that keys should not be
+// treated as commands
+func (p *Picker) typing() bool {
+	return p.searchFlex.HasFocus() || p.hexInput.HasFocus() || p.hueInput.HasFocus() || p.filterInput.HasFocus() || p.saveInput.HasFocus()
+}
+
+// Hex Input setup --------------------------------------------------------
+
+func (p *Picker) hexInputSetup() {
+	p.hexInput.SetLabel("Hex: #")
+	p.hexInput.SetFieldWidth(8)
+	p.hexInput.SetFieldNoteTextColor(tcell.ColorRed)
+	p.hexInput.SetChangedFunc(p.hexInputChangedFunc)
+	p.hexInput.SetDoneFunc(p.hexInputDoneFunc)
+}
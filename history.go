@@ -0,0 +1,171 @@
+package cpick
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	color "github.com/ethanbaker/colors"
+	"github.com/ethanbaker/cpick/cview"
+	"github.com/gdamore/tcell/v2"
+)
+
+// The amount of selected colors that are remembered
+const HISTORY_SIZE = 10
+
+// History Handlers -------------------------------------------------------
+
+func (p *Picker) historyDoneFunc(key tcell.Key) {
+	switch {
+	case p.keyMatches("quit", key, 0):
+		p.app.Stop()
+	case p.keyMatches("switch-table", key, 0):
+		p.switchTable()
+	}
+}
+
+func (p *Picker) historySelectedFunc(row int, column int) {
+	if column >= len(p.history) {
+		return
+	}
+
+	hsv := color.HextoHSV(color.Hex(p.history[column]))
+	p.selectColor(hsv, hsv)
+}
+
+func (p *Picker) historySelectionChangedFunc(row int, column int) {
+	if column >= len(p.history) {
+		return
+	}
+
+	hsv := color.HextoHSV(color.Hex(p.history[column]))
+	p.setColorValues(hsv, p.darkHBlock, p.darkHText, hsv, p.lightHBlock, p.lightHText)
+}
+
+// Switch focus between the history table and the hue screen tables
+func (p *Picker) toggleHistory() {
+	if p.historyTable.HasFocus() {
+		p.app.SetFocus(p.hFocus)
+		p.historyTable.SetSelectedStyle(tcell.ColorDefault, tcell.ColorDefault, tcell.AttrBold)
+	} else if len(p.history) > 0 && (p.hTable.HasFocus() || p.colorPages.HasFocus()) {
+		p.app.SetFocus(p.historyTable)
+		p.historyTable.SetSelectedStyle(tcell.ColorDefault, tcell.ColorGray, tcell.AttrNone)
+		p.historySelectionChangedFunc(p.historyTable.GetSelection())
+	}
+}
+
+// History setup ----------------------------------------------------------
+
+func (p *Picker) historySetup() {
+	p.history = loadHistory()
+
+	p.historyTable.SetSelectable(true, true)
+	p.historyTable.SetSelectedStyle(tcell.ColorDefault, tcell.ColorDefault, tcell.AttrBold)
+	p.historyTable.SetScrollBarVisibility(cview.ScrollBarNever)
+	p.historyTable.SetDoneFunc(p.historyDoneFunc)
+	p.historyTable.SetSelectedFunc(p.historySelectedFunc)
+	p.historyTable.SetSelectionChangedFunc(p.historySelectionChangedFunc)
+
+	p.drawHistoryTable()
+}
+
+func (p *Picker) drawHistoryTable() {
+	p.historyTable.Clear()
+
+	if len(p.history) == 0 {
+		cell := cview.NewTableCell("  No colors have been selected yet")
+		cell.SetSelectable(false)
+		p.historyTable.SetCell(0, 0, cell)
+		return
+	}
+
+	for i, hex := range p.history {
+		rgb := color.HextoRGB(color.Hex(hex))
+
+		text := fmt.Sprintf("  ████ #%v", hex)
+		if tag := p.readableTag(rgb); tag != "" {
+			text = fmt.Sprintf("  ████ %v#%v", tag, hex)
+		}
+
+		cell := cview.NewTableCell(text)
+		cell.SetTextColor(p.displayColor(rgb))
+		p.historyTable.SetCell(0, i, cell)
+	}
+	p.historyTable.Select(0, 0)
+}
+
+// Helper functions -------------------------------------------------------
+
+// Add a selected color to the front of the history
+func (p *Picker) addHistory(hex color.Hex) {
+	h := strings.ToLower(string(hex))
+
+	colors := []string{h}
+	for _, v := range p.history {
+		if v != h && len(colors) < HISTORY_SIZE {
+			colors = append(colors, v)
+		}
+	}
+	p.history = colors
+
+	p.saveHistory()
+}
+
+// Get the path of a file in the cpick config directory
+func configPath(name string) (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(homeDir, ".config", "cpick", name), nil
+}
+
+// Load the history file. A missing or invalid file results in no history.
+func loadHistory() []string {
+	path, err := configPath("history.json")
+	if err != nil {
+		return nil
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+
+	var colors []string
+	if err := json.Unmarshal(raw, &colors); err != nil {
+		return nil
+	}
+
+	// Only keep valid hex values
+	var valid []string
+	for _, v := range colors {
+		if validHex(v) && len(valid) < HISTORY_SIZE {
+			valid = append(valid, strings.ToLower(v))
+		}
+	}
+
+	return valid
+}
+
+// Save the history to the history file
+func (p *Picker) saveHistory() error {
+	path, err := configPath("history.json")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	raw, err := json.MarshalIndent(p.history, "", "    ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, raw, 0644)
+}
@@ -0,0 +1,87 @@
+package cpick
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"unicode"
+
+	color "github.com/ethanbaker/colors"
+	"github.com/gdamore/tcell/v2"
+)
+
+// Hue Input Handlers -----------------------------------------------------
+
+func (p *Picker) hueInputChangedFunc(text string) {
+	if text == "" {
+		p.hueInput.ResetFieldNote()
+		return
+	}
+
+	h, err := parseHueInput(text)
+	if err != nil {
+		p.hueInput.SetFieldNote(err.Error())
+		return
+	}
+	p.hueInput.ResetFieldNote()
+
+	// Show the typed hue on the hue screen
+	hsv := color.HSV{H: h, S: 100, V: 100}
+	p.setColorValues(hsv, p.darkHBlock, p.darkHText, hsv, p.lightHBlock, p.lightHText)
+}
+
+func (p *Picker) hueInputDoneFunc(key tcell.Key) {
+	switch key {
+	// Go back to the hue screen without selecting a hue
+	case tcell.KeyEscape:
+		p.hueInput.SetText("")
+		p.hueInput.ResetFieldNote()
+		p.app.SetFocus(p.hFocus)
+
+	// Select the column of the typed hue on the hue table
+	case tcell.KeyEnter:
+		h, err := parseHueInput(p.hueInput.GetText())
+		if err != nil {
+			p.hueInput.SetFieldNote(err.Error())
+			return
+		}
+
+		p.hueInput.SetText("")
+		p.hueInput.ResetFieldNote()
+		p.selectHue(h)
+	}
+}
+
+// Parse the text of the hue input into a hue between 0 and 359
+func parseHueInput(text string) (int, error) {
+	h, err := strconv.Atoi(strings.TrimSpace(text))
+	if err != nil || h < 0 || h > 359 {
+		return 0, errors.New("Hue must be between 0 and 359")
+	}
+
+	return h, nil
+}
+
+// Move the hue table to the column of the given hue
+func (p *Picker) selectHue(h int) {
+	p.hFocus = p.hTable
+	p.app.SetFocus(p.hTable)
+	p.hTable.Select(0, h/p.hueStep)
+
+	// The column covers more than one hue, so show the exact hue
+	hsv := color.HSV{H: h, S: 100, V: 100}
+	p.setColorValues(hsv, p.darkHBlock, p.darkHText, hsv, p.lightHBlock, p.lightHText)
+}
+
+// Hue Input setup --------------------------------------------------------
+
+func (p *Picker) hueInputSetup() {
+	p.hueInput.SetLabel("Hue: ")
+	p.hueInput.SetFieldWidth(5)
+	p.hueInput.SetFieldNoteTextColor(tcell.ColorRed)
+	p.hueInput.SetAcceptanceFunc(func(text string, ch rune) bool {
+		return len(text) <= 3 && (ch == 0 || unicode.IsDigit(ch))
+	})
+	p.hueInput.SetChangedFunc(p.hueInputChangedFunc)
+	p.hueInput.SetDoneFunc(p.hueInputDoneFunc)
+}
@@ -0,0 +1,200 @@
+package cpick
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// Default keys for each action that can be remapped in the config file. Keys
+// are written as the rune itself (EX: "q") or as the tcell key name (EX:
+// "Esc", "Tab", "Ctrl-F").
+var defaultKeybindings = map[string][]string{
+	"quit":            {"q", "Esc"},
+	"help":            {"`"},
+	"search":          {"Ctrl-F", "?"},
+	"switch-table":    {"Tab"},
+	"copy":            {"y"},
+	"copy-escape":     {"Y"},
+	"history":         {"p"},
+	"harmonies":       {"H"},
+	"simulate":        {"V"},
+	"grayscale":       {"w"},
+	"random":          {"r"},
+	"color-mode":      {"M"},
+	"undo":            {"u"},
+	"redo":            {"Ctrl-R"},
+	"reload":          {"F5"},
+	"ramp":            {"T"},
+	"large-preview":   {"L"},
+	"emphasis":        {"f"},
+	"palette-256":     {"X"},
+	"palette-16":      {"x"},
+	"sliders":         {"S"},
+	"gradient":        {"e"},
+	"cmyk":            {"K"},
+	"add-favorite":    {"b"},
+	"favorites":       {"B"},
+	"named-list":      {"I"},
+	"save":            {"s"},
+	"hex-input":       {"#"},
+	"hue-input":       {":"},
+	"switch-presets":  {" "},
+	"sv-half":         {"t"},
+	"alpha-down":      {"a"},
+	"alpha-up":        {"A"},
+	"value-up":        {"+"},
+	"value-down":      {"-"},
+	"saturation-up":   {"]"},
+	"saturation-down": {"["},
+	"next-page":       {"C"},
+	"prev-page":       {"c"},
+	"first-page":      {"<"},
+	"last-page":       {">"},
+	"sort":            {"o"},
+	"filter":          {"/"},
+	"next-match":      {"N"},
+	"prev-match":      {"n"},
+	"gradient-more":   {"+"},
+	"gradient-fewer":  {"-"},
+	"remove-favorite": {"d"},
+}
+
+// keyList is a list of keys that can also be written as a single string in
+// the config file
+type keyList []string
+
+func (k *keyList) UnmarshalJSON(data []byte) error {
+	var key string
+	if err := json.Unmarshal(data, &key); err == nil {
+		*k = keyList{key}
+		return nil
+	}
+
+	var keys []string
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return err
+	}
+
+	*k = keys
+	return nil
+}
+
+// configFile holds the settings read from ~/.config/cpick/config.json
+type configFile struct {
+	Keybindings map[string]keyList `json:"keybindings"`
+	Theme       string             `json:"theme"`
+	Emphasis    string             `json:"emphasis"`
+	CacheColors bool               `json:"cacheColors"`
+}
+
+// Load the config file. A missing or invalid file results in an empty config.
+func loadConfigFile() configFile {
+	var config configFile
+
+	path, err := configPath("config.json")
+	if err != nil {
+		return config
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return config
+	}
+
+	if err := json.Unmarshal(raw, &config); err != nil {
+		return configFile{}
+	}
+
+	return config
+}
+
+// Save a single setting to the config file, keeping the other settings. An
+// invalid config file is not overwritten.
+func saveConfigSetting(name string, value interface{}) error {
+	path, err := configPath("config.json")
+	if err != nil {
+		return err
+	}
+
+	settings := map[string]interface{}{}
+	if raw, err := os.ReadFile(path); err == nil {
+		if err := json.Unmarshal(raw, &settings); err != nil {
+			return err
+		}
+	}
+	settings[name] = value
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	raw, err := json.MarshalIndent(settings, "", "    ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, raw, 0644)
+}
+
+// Get the keybindings from the config file, using the defaults for any
+// action that is not given
+func loadKeybindings(config configFile) map[string][]string {
+	bindings := make(map[string][]string, len(defaultKeybindings))
+	for action, keys := range defaultKeybindings {
+		bindings[action] = keys
+		if custom, ok := config.Keybindings[action]; ok {
+			bindings[action] = custom
+		}
+	}
+
+	return bindings
+}
+
+// Get a help text with the keys currently bound to each action filled in
+func (p *Picker) helpText(text string) string {
+	replacements := make([]string, 0, 2*len(p.keybindings))
+	for action, keys := range p.keybindings {
+		names := make([]string, len(keys))
+		for i, key := range keys {
+			names[i] = key
+			if key == " " {
+				names[i] = "space"
+			}
+		}
+
+		replacements = append(replacements, "{"+action+"}", strings.Join(names, " or "))
+	}
+
+	return strings.NewReplacer(replacements...).Replace(text)
+}
+
+// Check if a key is bound to an action. Keys are matched by their rune if
+// they have a printable one, or by their tcell key name otherwise.
+func (p *Picker) keyMatches(action string, key tcell.Key, ch rune) bool {
+	for _, v := range p.keybindings[action] {
+		if unicode.IsPrint(ch) && v == string(ch) {
+			return true
+		}
+		if key != tcell.KeyRune && v == tcell.KeyNames[key] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Check if an event is bound to an action. Escape, Tab, and Backtab are
+// passed to the done functions of the tables, so they are not matched here.
+func (p *Picker) eventMatches(action string, event *tcell.EventKey) bool {
+	switch event.Key() {
+	case tcell.KeyEscape, tcell.KeyTab, tcell.KeyBacktab:
+		return false
+	}
+
+	return p.keyMatches(action, event.Key(), event.Rune())
+}
@@ -0,0 +1,53 @@
+package cpick
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// lastColor type used to save the last selected color
+type lastColor struct {
+	Hex string `json:"hex"`
+}
+
+// Load the hex value of the last selected color. A missing or invalid file
+// results in no color.
+func loadLastColor() string {
+	path, err := configPath("last.json")
+	if err != nil {
+		return ""
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+
+	var last lastColor
+	if err := json.Unmarshal(raw, &last); err != nil || !validHex(last.Hex) {
+		return ""
+	}
+
+	return strings.ToLower(last.Hex)
+}
+
+// Save the hex value of the last selected color
+func saveLastColor(hex string) error {
+	path, err := configPath("last.json")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	raw, err := json.MarshalIndent(lastColor{strings.ToLower(hex)}, "", "    ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, raw, 0644)
+}
@@ -0,0 +1,102 @@
+package cpick
+
+import (
+	"math"
+
+	"github.com/ethanbaker/cpick/cview"
+)
+
+// Sizes below which the compact layout is used
+const COMPACT_HEIGHT = 24
+const COMPACT_WIDTH = 80
+
+// Default and limits of the number of rows of the saturation-value table.
+// Each row shows two values using a half block, so 50 rows show every other
+// value and 100 rows move one value at a time.
+const (
+	DEFAULT_SV_ROWS = 50
+	MIN_SV_ROWS     = 10
+	MAX_SV_ROWS     = 100
+)
+
+// Set the layout variables from the size of the screen
+func (p *Picker) setScreenSize(width int, height int) {
+	p.smallWidth = width < BREAKPOINT_WIDTH
+	p.smallHeight = height < BREAKPOINT_HEIGHT
+	p.compact = width < COMPACT_WIDTH || height < COMPACT_HEIGHT
+	p.showRamp = !p.smallWidth && height >= BREAKPOINT_HEIGHT+RAMP_SIZE+1
+	p.screenHeight = height
+
+	// Use fewer hue cells so the whole hue table fits on the screen
+	p.hueStep = 2
+	if width > 0 && width < 360/p.hueStep {
+		p.hueStep = int(math.Ceil(360 / float64(width)))
+		if p.hueStep%2 == 1 {
+			p.hueStep++
+		}
+	}
+}
+
+// Check if the light tint is shown next to the dark tint, which needs a large
+// screen and is turned off by single tint mode
+func (p *Picker) showLightTint() bool {
+	return !p.smallWidth && !p.smallHeight && !p.singleTint
+}
+
+// Set the number of rows of the saturation-value table, keeping it within
+// the limits and making sure the whole table (with the black row) fits on the
+// screen
+func (p *Picker) setSVRows(rows int) {
+	rows = int(math.Max(MIN_SV_ROWS, math.Min(MAX_SV_ROWS, float64(rows))))
+	if p.screenHeight > 0 && rows > p.screenHeight-1 {
+		rows = int(math.Max(MIN_SV_ROWS, float64(p.screenHeight-1)))
+	}
+
+	p.svRows = rows
+}
+
+// Get the value shown by a half row of the saturation-value table, where the
+// top half of row r is 2r and the bottom half is 2r+1. The values go from 100
+// at the top to 0 at the black row.
+func (p *Picker) svValue(half int) int {
+	if half >= 2*p.svRows {
+		return 0
+	}
+
+	return 100 - int(math.Round(float64(half)*100/float64(2*p.svRows)))
+}
+
+// Get the half row of the saturation-value table closest to a value, where the
+// top half of row r is 2r and the bottom half is 2r+1
+func (p *Picker) svHalfRow(v int) int {
+	half := int(math.Round(float64(100-v) * float64(2*p.svRows) / 100))
+	if half >= 2*p.svRows {
+		// The black row isn't split into halves
+		return 2 * p.svRows
+	}
+
+	return half
+}
+
+// Show either the color values or the preset colors on the hue screen in the
+// compact layout
+func (p *Picker) updateCompactLayout(focus cview.Primitive) {
+	if !p.compact || p.hLowerFlex == nil {
+		return
+	}
+
+	presets := focus == p.colorPages
+	for _, v := range p.colorInfo {
+		if focus == v.table {
+			presets = true
+		}
+	}
+
+	if presets {
+		p.hLowerFlex.ResizeItem(p.hColorFlex, 0, 0)
+		p.hLowerFlex.ResizeItem(p.jsonColors, 0, 1)
+	} else {
+		p.hLowerFlex.ResizeItem(p.hColorFlex, 0, 1)
+		p.hLowerFlex.ResizeItem(p.jsonColors, 0, 0)
+	}
+}
@@ -0,0 +1,60 @@
+package cpick
+
+import (
+	"github.com/ethanbaker/cpick/cview"
+	"github.com/gdamore/tcell/v2"
+)
+
+// Find the cell of a table at a position on the screen, using where each cell
+// was drawn the last time the table was drawn
+func tableCellAt(table *cview.Table, x, y int) (int, int, bool) {
+	rowOffset, columnOffset := table.GetOffset()
+	for row := rowOffset; row < table.GetRowCount(); row++ {
+		for column := columnOffset; column < table.GetColumnCount(); column++ {
+			cell := table.GetCell(row, column)
+			if cell == nil {
+				continue
+			}
+
+			cellX, cellY, width := cell.GetLastPosition()
+			if y == cellY && x >= cellX && x < cellX+width {
+				return row, column, true
+			}
+		}
+	}
+
+	return 0, 0, false
+}
+
+// Get a mouse capture function for a table. Clicking or dragging with the
+// left mouse button moves the selection to the color under the mouse, and
+// clicked is called with the cell of a finished click (if it isn't nil).
+func (p *Picker) tableMouseCapture(table *cview.Table, clicked func(row int, column int)) func(action cview.MouseAction, event *tcell.EventMouse) (cview.MouseAction, *tcell.EventMouse) {
+	return func(action cview.MouseAction, event *tcell.EventMouse) (cview.MouseAction, *tcell.EventMouse) {
+		dragging := action == cview.MouseMove && event.Buttons()&tcell.Button1 != 0
+		if action != cview.MouseLeftDown && action != cview.MouseLeftClick && !dragging {
+			return action, event
+		}
+
+		x, y := event.Position()
+		row, column, ok := tableCellAt(table, x, y)
+		if !ok {
+			return action, event
+		}
+
+		if !table.HasFocus() {
+			p.app.SetFocus(table)
+		}
+		table.Select(row, column)
+
+		if action == cview.MouseLeftClick && clicked != nil {
+			clicked(row, column)
+		}
+
+		// The application only draws after mouse events that a primitive
+		// consumed, which doesn't include the events taken by a capture
+		p.app.Draw()
+
+		return action, nil
+	}
+}
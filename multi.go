@@ -0,0 +1,37 @@
+package cpick
+
+// Add a selected color to the picked colors and return true if more colors
+// still need to be selected
+func (p *Picker) pickColor(c ColorValues) bool {
+	p.pickedColors = append(p.pickedColors, c)
+	if len(p.pickedColors) >= p.pickCount {
+		return false
+	}
+
+	// Go back to the start so the next color can be picked. The hue screen
+	// is disabled in grayscale mode, so stay on the saturation-value table
+	if p.grayscale {
+		p.app.SetFocus(p.svTable)
+		return true
+	}
+
+	p.pages.SwitchToPage("Hue page")
+	p.app.SetFocus(p.hFocus)
+
+	return true
+}
+
+// StartMulti function starts the cpick application using the given
+// configuration and lets the user select count colors in one session. After
+// each selection the user is returned to the hue screen, and the application
+// stops once count colors are selected. If the application is quit early, the
+// colors that were already selected are returned. If no colors were selected,
+// ErrCanceled is returned.
+func StartMulti(config Config, count int) ([]ColorValues, error) {
+	p := NewPicker(config)
+	if _, err := p.start(count); err != nil {
+		return nil, err
+	}
+
+	return p.pickedColors, nil
+}
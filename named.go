@@ -0,0 +1,119 @@
+package cpick
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	color "github.com/ethanbaker/colors"
+	"github.com/ethanbaker/cpick/cview"
+)
+
+// Named Color Handlers ---------------------------------------------------
+
+func (p *Picker) namedListDoneFunc() {
+	p.hideNamedList()
+}
+
+func (p *Picker) namedListSelectedFunc(index int, item *cview.ListItem) {
+	if index < 0 || index >= len(p.namedColors) {
+		return
+	}
+	c := p.namedColors[index]
+
+	hsv := color.HextoHSV(color.Hex(strings.TrimPrefix(c.VALUE, "#")))
+	p.selectColorValues(newColorValues(hsv, c.NAME, p.alpha))
+}
+
+// Show every named preset color in a single list, or go back if the list is
+// already shown
+func (p *Picker) toggleNamedList() {
+	if p.namedList.HasFocus() {
+		p.hideNamedList()
+		return
+	}
+
+	if p.noPresets {
+		p.setStatus("The preset colors are hidden, so there are no named colors to list", true)
+		return
+	}
+
+	p.namedReturnPage, _ = p.pages.GetFrontPage()
+	p.namedFocus = p.app.GetFocus()
+
+	p.drawNamedList()
+	p.pages.SwitchToPage("Named Color page")
+	p.app.SetFocus(p.namedList)
+}
+
+func (p *Picker) hideNamedList() {
+	p.pages.SwitchToPage(p.namedReturnPage)
+	p.app.SetFocus(p.namedFocus)
+}
+
+// Named Color setup ------------------------------------------------------
+
+func (p *Picker) namedListSetup() {
+	p.namedList.SetSelectedFunc(p.namedListSelectedFunc)
+	p.namedList.SetDoneFunc(p.namedListDoneFunc)
+	p.namedList.SetScrollBarVisibility(cview.ScrollBarNever)
+	p.namedList.SetHighlightFullLine(true)
+
+	title := cview.NewTextView()
+	title.SetTextAlign(cview.AlignCenter)
+	title.SetText("Named preset colors")
+
+	help := cview.NewTextView()
+	help.SetTextAlign(cview.AlignRight)
+	help.SetText("Press enter to select a color and I or escape to go back")
+
+	p.namedFlex.SetDirection(cview.FlexRow)
+	p.namedFlex.AddItem(title, 1, 0, false)
+	p.namedFlex.AddItem(p.namedList, 0, 1, true)
+	p.namedFlex.AddItem(help, 1, 0, false)
+}
+
+// Fill the list with the named colors of every preset color page
+func (p *Picker) drawNamedList() {
+	p.namedColors = p.getNamedColors()
+
+	p.namedList.Clear()
+	for _, c := range p.namedColors {
+		rgb := p.simulateRGB(color.HextoRGB(color.Hex(strings.TrimPrefix(c.VALUE, "#"))))
+
+		item := cview.NewListItem(fmt.Sprintf("[#%02x%02x%02x]██████[-]  %v", rgb.R, rgb.G, rgb.B, cview.Escape(c.NAME)))
+		item.SetSecondaryText("          " + strings.ToLower(c.VALUE))
+		p.namedList.AddItem(item)
+	}
+}
+
+// Helper functions -------------------------------------------------------
+
+// Get the named colors of every preset color page in alphabetical order. A
+// color that is on more than one page is only listed once.
+func (p *Picker) getNamedColors() []jsonColor {
+	seen := make(map[jsonColor]bool)
+	colors := make([]jsonColor, 0)
+
+	for _, info := range p.colorInfo {
+		for _, c := range info.all {
+			if c.NAME == "" || !validHex(strings.TrimPrefix(c.VALUE, "#")) {
+				continue
+			}
+
+			c.VALUE = "#" + strings.ToLower(strings.TrimPrefix(c.VALUE, "#"))
+			if seen[c] {
+				continue
+			}
+			seen[c] = true
+
+			colors = append(colors, c)
+		}
+	}
+
+	sort.SliceStable(colors, func(i, j int) bool {
+		return strings.ToLower(colors[i].NAME) < strings.ToLower(colors[j].NAME)
+	})
+
+	return colors
+}
@@ -0,0 +1,106 @@
+package cpick
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+
+	color "github.com/ethanbaker/colors"
+)
+
+// The amount of close preset colors that are found when searching for a hex
+// value
+const NEAREST_SIZE = 10
+
+// Get the name of the preset color closest to a color and its Euclidean
+// distance in RGB space
+func nearestColorName(presets []jsonColor, hsv color.HSV) (string, float64) {
+	c, dist := nearestColor(presets, hsv)
+	return c.NAME, dist
+}
+
+// Get the preset color closest to a color and its Euclidean distance in RGB
+// space
+func nearestColor(presets []jsonColor, hsv color.HSV) (jsonColor, float64) {
+	rgb := color.HSVtoRGB(hsv)
+
+	var nearest jsonColor
+	dist := math.Inf(1)
+	for _, c := range presets {
+		// Skip colors without a name
+		if c.NAME == "" {
+			continue
+		}
+
+		d := rgbDistance(rgb, c.RGB)
+		if d < dist {
+			nearest = c
+			dist = d
+		}
+	}
+
+	return nearest, dist
+}
+
+// Get the locations (page, column, row) of the preset colors closest to a
+// color, with the closest color first
+func (p *Picker) nearestColorLocations(rgb color.RGB) [][]int {
+	var locations [][]int
+	var dists []float64
+	for i := 0; i < len(p.colorInfo); i++ {
+		for j, c := range p.colorInfo[i].colors[:p.colorInfo[i].length] {
+			locations = append(locations, []int{i, j / 9, j % 9})
+			dists = append(dists, rgbDistance(rgb, c.RGB))
+		}
+	}
+
+	order := make([]int, len(locations))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return dists[order[i]] < dists[order[j]]
+	})
+
+	var nearest [][]int
+	for i := 0; i < len(order) && i < NEAREST_SIZE; i++ {
+		nearest = append(nearest, locations[order[i]])
+	}
+
+	return nearest
+}
+
+// Get the Euclidean distance between two colors in RGB space
+func rgbDistance(a color.RGB, b color.RGB) float64 {
+	return math.Sqrt(math.Pow(float64(a.R-b.R), 2) + math.Pow(float64(a.G-b.G), 2) + math.Pow(float64(a.B-b.B), 2))
+}
+
+// Generate a name for a color from the name of the closest preset color and
+// the hex value of the color (EX: "darkorange-ff8800")
+func generateColorName(presets []jsonColor, hsv color.HSV) string {
+	name, _ := nearestColorName(presets, hsv)
+	if name == "" {
+		name = "custom"
+	}
+
+	// Only keep letters and numbers, separating words with dashes
+	slug := strings.Join(strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}), "-")
+
+	return slug + "-" + string(color.HSVtoHex(hsv))
+}
+
+// Update the nearest color text with the preset color closest to a color
+func (p *Picker) setNearestText(hsv color.HSV) {
+	c, _ := nearestColor(p.presetColors(), hsv)
+	if c.NAME == "" {
+		p.nearestText.SetText("")
+		return
+	}
+
+	hex := strings.ToLower(strings.TrimPrefix(c.VALUE, "#"))
+	p.nearestText.SetText(fmt.Sprintf("≈ %v (#%v)", strings.Title(c.NAME), hex))
+}
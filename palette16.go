@@ -0,0 +1,142 @@
+package cpick
+
+import (
+	"fmt"
+	"math"
+
+	color "github.com/ethanbaker/colors"
+	"github.com/ethanbaker/cpick/cview"
+	"github.com/gdamore/tcell/v2"
+)
+
+// Names of the 16 standard terminal colors
+var ansi16Names = [...]string{
+	"black", "red", "green", "yellow", "blue", "magenta", "cyan", "white",
+	"bright black", "bright red", "bright green", "bright yellow", "bright blue", "bright magenta", "bright cyan", "bright white",
+}
+
+// 16 Color Handlers ------------------------------------------------------
+
+func (p *Picker) palette16DoneFunc(key tcell.Key) {
+	if key == tcell.KeyEscape {
+		p.hide16Palette()
+	}
+}
+
+func (p *Picker) palette16SelectedFunc(row int, column int) {
+	p.selectColorValues(p.ansi16ColorValues(row*8 + column))
+}
+
+func (p *Picker) palette16SelectionChangedFunc(row int, column int) {
+	index := row*8 + column
+	p.palette16Text.SetText(fmt.Sprintf("Name: %v    SGR code: %v    Hex: #%v", ansi16Names[index], ansi16Code(index), ansiStandardColors[index]))
+}
+
+// Show the 16 standard terminal colors, or go back if they are already shown
+func (p *Picker) toggle16Palette() {
+	if p.palette16Table.HasFocus() {
+		p.hide16Palette()
+		return
+	}
+
+	p.palette16ReturnPage, _ = p.pages.GetFrontPage()
+	p.palette16Focus = p.app.GetFocus()
+
+	p.drawPalette16Table()
+	p.pages.SwitchToPage("16 Color page")
+	p.app.SetFocus(p.palette16Table)
+}
+
+func (p *Picker) hide16Palette() {
+	p.pages.SwitchToPage(p.palette16ReturnPage)
+	p.app.SetFocus(p.palette16Focus)
+}
+
+// 16 Color setup ---------------------------------------------------------
+
+func (p *Picker) palette16Setup() {
+	p.palette16Table.SetSelectable(true, true)
+	p.palette16Table.SetCellPadding(2, 1)
+	p.palette16Table.SetScrollBarVisibility(cview.ScrollBarNever)
+	p.palette16Table.SetSelectedStyle(tcell.ColorDefault, tcell.ColorGray, tcell.AttrNone)
+	p.palette16Table.SetDoneFunc(p.palette16DoneFunc)
+	p.palette16Table.SetSelectedFunc(p.palette16SelectedFunc)
+	p.palette16Table.SetSelectionChangedFunc(p.palette16SelectionChangedFunc)
+
+	title := cview.NewTextView()
+	title.SetTextAlign(cview.AlignCenter)
+	title.SetText("16 standard terminal colors")
+
+	help := cview.NewTextView()
+	help.SetTextAlign(cview.AlignRight)
+	help.SetText("Press enter to select a color and x or escape to go back")
+
+	p.palette16Flex.SetDirection(cview.FlexRow)
+	p.palette16Flex.AddItem(title, 1, 0, false)
+	// Each of the two rows takes three lines with the padding, and the table
+	// draws separators on the padding lines below the last row
+	p.palette16Flex.AddItem(p.palette16Table, 6, 0, true)
+	p.palette16Flex.AddItem(p.palette16Text, 1, 0, false)
+	p.palette16Flex.AddItem(help, 0, 1, false)
+
+	p.drawPalette16Table()
+	p.palette16Table.Select(0, 0)
+}
+
+func (p *Picker) drawPalette16Table() {
+	for i, name := range ansi16Names {
+		rgb := ansi256RGB(i)
+
+		text := fmt.Sprintf("██████  %v", name)
+		if tag := p.readableTag(rgb); tag != "" {
+			text = fmt.Sprintf("██████  %v%v", tag, name)
+		}
+
+		cell := cview.NewTableCell(text)
+		cell.SetTextColor(p.displayColor(rgb))
+		p.palette16Table.SetCell(i/8, i%8, cell)
+	}
+}
+
+// Helper functions -------------------------------------------------------
+
+// Get the SGR code of the foreground of a standard terminal color (EX: 31
+// for red and 91 for bright red)
+func ansi16Code(index int) int {
+	if index < 8 {
+		return 30 + index
+	}
+	return 90 + index - 8
+}
+
+// Create the color values of a standard terminal color, using its nominal
+// RGB value and the escape code of the color
+func (p *Picker) ansi16ColorValues(index int) ColorValues {
+	c := newRGBColorValues(ansi256RGB(index), p.presetColors())
+	c.Ansi = color.Ansi(fmt.Sprintf("\x1b[%vm", ansi16Code(index)))
+	c.Name = ansi16Names[index]
+	c.ColorIndex = index
+
+	return c
+}
+
+// Ansi16Code function returns the SGR code (30-37 or 90-97) of the foreground
+// of the standard terminal color that a color was picked from. If the color
+// isn't one of the 16 standard colors, the code of the closest standard color
+// is returned.
+func Ansi16Code(c ColorValues) int {
+	if c.ColorIndex >= 0 && c.ColorIndex < 16 {
+		return ansi16Code(c.ColorIndex)
+	}
+
+	nearest := 0
+	dist := math.Inf(1)
+	for i := range ansiStandardColors {
+		if d := rgbDistance(c.RGB, ansi256RGB(i)); d < dist {
+			nearest = i
+			dist = d
+		}
+	}
+
+	return ansi16Code(nearest)
+}
@@ -0,0 +1,136 @@
+package cpick
+
+import (
+	"fmt"
+	"math"
+
+	color "github.com/ethanbaker/colors"
+	"github.com/ethanbaker/cpick/cview"
+	"github.com/gdamore/tcell/v2"
+)
+
+// Levels of the red, green, and blue values of the 6x6x6 color cube
+var ansiCubeLevels = [...]int{0, 95, 135, 175, 215, 255}
+
+// Hex values of the 16 standard colors (using the xterm defaults)
+var ansiStandardColors = [...]color.Hex{
+	"000000", "800000", "008000", "808000", "000080", "800080", "008080", "c0c0c0",
+	"808080", "ff0000", "00ff00", "ffff00", "0000ff", "ff00ff", "00ffff", "ffffff",
+}
+
+// 256 Color Handlers -----------------------------------------------------
+
+func (p *Picker) palette256DoneFunc(key tcell.Key) {
+	if key == tcell.KeyEscape {
+		p.hide256Palette()
+	}
+}
+
+func (p *Picker) palette256SelectedFunc(row int, column int) {
+	p.selectColorValues(p.ansi256ColorValues(row*16 + column))
+}
+
+func (p *Picker) palette256SelectionChangedFunc(row int, column int) {
+	index := row*16 + column
+	rgb := ansi256RGB(index)
+	p.palette256Text.SetText(fmt.Sprintf("Index: %v    Hex: #%v    RGB: %v, %v, %v", index, color.RGBtoHex(rgb), rgb.R, rgb.G, rgb.B))
+}
+
+// Show the 256 color palette, or go back if it is already shown
+func (p *Picker) toggle256Palette() {
+	if p.palette256Table.HasFocus() {
+		p.hide256Palette()
+		return
+	}
+
+	p.palette256ReturnPage, _ = p.pages.GetFrontPage()
+	p.palette256Focus = p.app.GetFocus()
+
+	p.drawPalette256Table()
+	p.pages.SwitchToPage("256 Color page")
+	p.app.SetFocus(p.palette256Table)
+}
+
+func (p *Picker) hide256Palette() {
+	p.pages.SwitchToPage(p.palette256ReturnPage)
+	p.app.SetFocus(p.palette256Focus)
+}
+
+// 256 Color setup --------------------------------------------------------
+
+func (p *Picker) palette256Setup() {
+	p.palette256Table.SetSelectable(true, true)
+	p.palette256Table.SetCellPadding(1, 0)
+	p.palette256Table.SetScrollBarVisibility(cview.ScrollBarNever)
+	p.palette256Table.SetSelectedStyle(tcell.ColorDefault, tcell.ColorGray, tcell.AttrNone)
+	p.palette256Table.SetDoneFunc(p.palette256DoneFunc)
+	p.palette256Table.SetSelectedFunc(p.palette256SelectedFunc)
+	p.palette256Table.SetSelectionChangedFunc(p.palette256SelectionChangedFunc)
+
+	title := cview.NewTextView()
+	title.SetTextAlign(cview.AlignCenter)
+	title.SetText("256 color palette")
+
+	help := cview.NewTextView()
+	help.SetTextAlign(cview.AlignRight)
+	help.SetText("Press enter to select a color and X or escape to go back")
+
+	p.palette256Flex.SetDirection(cview.FlexRow)
+	p.palette256Flex.AddItem(title, 1, 0, false)
+	p.palette256Flex.AddItem(p.palette256Table, 16, 0, true)
+	p.palette256Flex.AddItem(p.palette256Text, 1, 0, false)
+	p.palette256Flex.AddItem(help, 0, 1, false)
+
+	p.drawPalette256Table()
+	p.palette256Table.Select(0, 0)
+}
+
+func (p *Picker) drawPalette256Table() {
+	for i := 0; i < 256; i++ {
+		cell := cview.NewTableCell("████")
+		cell.SetTextColor(p.displayColor(ansi256RGB(i)))
+		p.palette256Table.SetCell(i/16, i%16, cell)
+	}
+}
+
+// Helper functions -------------------------------------------------------
+
+// Get the RGB value of a color of the 256 color palette
+func ansi256RGB(index int) color.RGB {
+	switch {
+	case index < 16:
+		return color.HextoRGB(ansiStandardColors[index])
+	case index < 232:
+		i := index - 16
+		return color.RGB{R: ansiCubeLevels[i/36], G: ansiCubeLevels[i/6%6], B: ansiCubeLevels[i%6]}
+	default:
+		v := 8 + (index-232)*10
+		return color.RGB{R: v, G: v, B: v}
+	}
+}
+
+// Get the index of the color of the 256 color palette closest to a color.
+// The 16 standard colors are skipped since terminals change them with their
+// themes.
+func nearestAnsi256(rgb color.RGB) int {
+	nearest := 16
+	dist := math.Inf(1)
+	for i := 16; i < 256; i++ {
+		if d := rgbDistance(rgb, ansi256RGB(i)); d < dist {
+			nearest = i
+			dist = d
+		}
+	}
+
+	return nearest
+}
+
+// Create the color values of a color of the 256 color palette, using the 256
+// color escape code
+func (p *Picker) ansi256ColorValues(index int) ColorValues {
+	c := newRGBColorValues(ansi256RGB(index), p.presetColors())
+	c.Ansi = color.Ansi(fmt.Sprintf("\x1b[38;5;%vm", index))
+	c.ColorIndex = index
+
+	return c
+}
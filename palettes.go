@@ -0,0 +1,55 @@
+package cpick
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// Read the palette files in ~/.config/cpick/palettes. Each color list of a
+// file becomes its own group of pages. If name is given, only the palette
+// file with that name (without .json) is read.
+func loadPalettes(name string) ([]jsonColorType, error) {
+	dir, err := configPath("palettes")
+	if err != nil {
+		return nil, err
+	}
+
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	cache := loadConfigFile().CacheColors
+
+	var lists []jsonColorType
+	found := false
+	for _, path := range paths {
+		base := strings.TrimSuffix(filepath.Base(path), ".json")
+		if name != "" && base != name {
+			continue
+		}
+		found = true
+
+		data, err := loadColorFile(path, cache)
+		if err != nil {
+			return nil, err
+		}
+
+		// Name the pages after the file so palettes can be told apart
+		for _, list := range data.COLORLIST {
+			if list.NAME == "" || list.NAME == base {
+				list.NAME = base
+			} else {
+				list.NAME = base + " " + list.NAME
+			}
+			lists = append(lists, list)
+		}
+	}
+
+	if name != "" && !found {
+		return nil, fmt.Errorf("Palette %q was not found in %v", name, dir)
+	}
+
+	return lists, nil
+}
@@ -0,0 +1,95 @@
+package cpick
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/ethanbaker/cpick/cview"
+	"github.com/gdamore/tcell/v2"
+)
+
+// The text of each paste, keyed by the key event that stands in for it
+var pastes = make(map[*tcell.EventKey]string)
+var pastesMutex sync.Mutex
+
+// pasteScreen wraps the screen of the application to catch bracketed pastes.
+// The application drops paste events and would get a key event for every
+// pasted character, so the characters of a paste are collected and passed
+// on as a single key event that carries the whole text.
+type pasteScreen struct {
+	tcell.Screen
+
+	pasting bool
+	text    strings.Builder
+}
+
+func newPasteScreen(screen tcell.Screen) *pasteScreen {
+	screen.EnablePaste()
+	return &pasteScreen{Screen: screen}
+}
+
+// PollEvent returns the next event of the screen, collecting the characters of
+// a paste into a single key event
+func (s *pasteScreen) PollEvent() tcell.Event {
+	for {
+		event := s.Screen.PollEvent()
+
+		switch event := event.(type) {
+		case *tcell.EventPaste:
+			if event.Start() {
+				s.pasting = true
+				s.text.Reset()
+				continue
+			}
+
+			s.pasting = false
+			return newPasteEvent(s.text.String())
+
+		case *tcell.EventKey:
+			if s.pasting {
+				// Line breaks and other keys can't be typed into the fields
+				if event.Key() == tcell.KeyRune {
+					s.text.WriteRune(event.Rune())
+				}
+				continue
+			}
+		}
+
+		return event
+	}
+}
+
+// Create the key event that stands in for a paste
+func newPasteEvent(text string) *tcell.EventKey {
+	event := tcell.NewEventKey(tcell.KeyRune, 0, tcell.ModNone)
+
+	pastesMutex.Lock()
+	pastes[event] = text
+	pastesMutex.Unlock()
+
+	return event
+}
+
+// Get the pasted text of a key event, if the event stands in for a paste
+func pastedText(event *tcell.EventKey) (string, bool) {
+	pastesMutex.Lock()
+	defer pastesMutex.Unlock()
+
+	text, ok := pastes[event]
+	delete(pastes, event)
+
+	return text, ok
+}
+
+// Add pasted text to the focused input field at once, so that the field only
+// updates its autocomplete once. Pastes are ignored anywhere else, so that
+// the pasted characters aren't taken as keys.
+func (p *Picker) pasteText(text string) {
+	input, ok := p.app.GetFocus().(*cview.InputField)
+	if !ok || text == "" {
+		return
+	}
+
+	input.SetText(input.GetText() + strings.TrimSpace(text))
+	input.Autocomplete()
+}
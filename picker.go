@@ -0,0 +1,401 @@
+package cpick
+
+import (
+	color "github.com/ethanbaker/colors"
+	"github.com/ethanbaker/cpick/cview"
+)
+
+// Picker type is a cpick application with its own screens and state, so
+// pickers don't affect each other and can run at the same time on different
+// screens. A picker can be started any number of times, with every start
+// beginning like the first one.
+type Picker struct {
+	config Config
+
+	// Whether the screen is narrower or shorter than the breakpoints
+	smallWidth  bool
+	smallHeight bool
+
+	// Elements on screen
+	app            *cview.Application
+	pages          *cview.Pages
+	hFlex          *cview.Flex
+	svFlex         *cview.Flex
+	hTable         *cview.Table
+	svTable        *cview.Table
+	darkHBlock     *cview.TextView
+	darkHText      *cview.TextView
+	lightHBlock    *cview.TextView
+	lightHText     *cview.TextView
+	darkSVBlock    *cview.TextView
+	darkSVText     *cview.TextView
+	lightSVBlock   *cview.TextView
+	lightSVText    *cview.TextView
+	colorPageTitle *cview.TextView
+	jsonColors     *cview.Flex
+	colorPages     *cview.Pages
+	colorPageIndex int
+	// The count typed before the next or previous page keys (EX: 3 in 3C), which
+	// moves that many pages at once
+	colorPageCount int
+	colorInfo      []jsonColorInfo
+	helpFlex       *cview.Flex
+	helpView       *cview.TextView
+	helpFocus      cview.Primitive
+	searchFlex     *cview.Flex
+	searchStatus   *cview.TextView
+	searchInput    *cview.InputField
+	searchNames    []string
+	searchIndexes  [][]int
+	searchIndex    int
+	hFocus         cview.Primitive
+	hHelpText      *cview.TextView
+	hue            int
+	alpha          float64
+	// Whether moving past either end of the hue table wraps around to the other end
+	hueWrap bool
+	// Whether the preset color panel is left out of the hue screen
+	noPresets bool
+	// The colors read from the color files, which name the colors when there
+	// are no preset color pages
+	fileColors []jsonColor
+	svStep     int
+	// Two short lines showing the exact hue, saturation, value, and hex value of
+	// the selected cell of the saturation-value table
+	svReadout   *cview.TextView
+	returnColor ColorValues
+
+	// Type used to format colors copied to the clipboard
+	clipboardType string
+
+	cmykFlex   *cview.Flex
+	cmykForm   *cview.Form
+	cmykBlock  *cview.TextView
+	cmykText   *cview.TextView
+	cmykSwatch *cview.TextView
+	cSlider    *cview.Slider
+	mSlider    *cview.Slider
+	ySlider    *cview.Slider
+	kSlider    *cview.Slider
+	// The page and primitive to return to when leaving the CMYK page
+	cmykReturnPage string
+	cmykFocus      cview.Primitive
+
+	// Index of the way colors are drawn
+	colorMode int
+	// Whether the terminal draws truecolor, so that users of terminals that don't
+	// can be told about the 256 color mode
+	truecolorTerminal bool
+
+	// The reference (background) color the contrast ratio is checked against
+	contrastReference    color.RGB
+	hasContrastReference bool
+	contrastText         *cview.TextView
+	bestText             *cview.TextView
+	sampleText           *cview.TextView
+
+	// The index of the emphasized color type, where 0 is no emphasis
+	emphasis int
+
+	// Hex values of the favorite colors, in the order they were added
+	favorites      []string
+	favoritesFlex  *cview.Flex
+	favoritesTable *cview.Table
+	// The page and primitive to return to when leaving the favorites page
+	favoritesReturnPage string
+	favoritesFocus      cview.Primitive
+
+	filterInput *cview.InputField
+	// Lowercase text that the names of the preset colors are filtered by
+	colorFilter string
+
+	gradientFlex  *cview.Flex
+	gradientTitle *cview.TextView
+	gradientTable *cview.Table
+	// Shows the start of the gradient while the end is being picked
+	gradientText *cview.TextView
+	// The endpoints of the gradient and the number of colors in it
+	gradientStart    color.HSV
+	gradientEnd      color.HSV
+	hasGradientStart bool
+	gradientSteps    int
+	// The page and primitive to return to when leaving the gradient page
+	gradientReturnPage string
+	gradientFocus      cview.Primitive
+
+	// If true, the saturation-value table only contains grays and the hue screen
+	// is disabled
+	grayscale bool
+
+	harmonyFlex  *cview.Flex
+	harmonyTitle *cview.TextView
+	harmonyTable *cview.Table
+	// The page and primitive to return to when leaving the harmony page
+	harmonyReturnPage string
+	harmonyFocus      cview.Primitive
+
+	hexInput *cview.InputField
+
+	// Hex values of the most recently selected colors (most recent first)
+	history      []string
+	historyTable *cview.Table
+
+	hueInput *cview.InputField
+
+	// The keys currently bound to each action
+	keybindings map[string][]string
+
+	// If true, the preset color panel is hidden on the hue screen unless the
+	// preset color table has focus
+	compact bool
+	// If true, the shades and tints are shown on the saturation-value screen
+	showRamp bool
+	// If true, only the light tint of the highlighted cell is shown, which is the
+	// color that is selected, instead of both tints of the half block
+	singleTint bool
+	// Number of degrees of hue in each cell of the hue table
+	hueStep int
+	// Number of rows of the saturation-value table, not counting the black row at
+	// the bottom
+	svRows int
+	// The half of the selected cell of the saturation-value table that is
+	// selected: 0 for the top half and 1 for the bottom half
+	svHalf int
+	// Height of the screen, or 0 if it isn't known yet
+	screenHeight int
+	// Panels of the hue screen that are swapped in the compact layout
+	hLowerFlex *cview.Flex
+	hColorFlex *cview.Flex
+
+	// The amount of colors that are selected before the application stops
+	pickCount int
+	// Colors that were selected in the current session (in selection order)
+	pickedColors []ColorValues
+
+	namedFlex *cview.Flex
+	namedList *cview.List
+	// The colors shown in the named color list, in the order they are listed
+	namedColors []jsonColor
+	// The page and primitive to return to when leaving the named color page
+	namedReturnPage string
+	namedFocus      cview.Primitive
+
+	nearestText *cview.TextView
+
+	palette16Flex  *cview.Flex
+	palette16Table *cview.Table
+	palette16Text  *cview.TextView
+	// The page and primitive to return to when leaving the 16 color page
+	palette16ReturnPage string
+	palette16Focus      cview.Primitive
+
+	palette256Flex  *cview.Flex
+	palette256Table *cview.Table
+	palette256Text  *cview.TextView
+	// The page and primitive to return to when leaving the 256 color page
+	palette256ReturnPage string
+	palette256Focus      cview.Primitive
+
+	// Name of the palette file the preset colors are restricted to
+	palette string
+
+	// If true, the color blocks take up more of the screen
+	largePreview bool
+	// Color blocks that are resized by the large preview
+	previewBlocks []previewBlock
+
+	rampTable *cview.Table
+
+	errorModal *cview.Modal
+	errorFocus cview.Primitive
+
+	// Whether selecting a color on the saturation-value table keeps the
+	// application running
+	sandbox bool
+	// Called with every color that is noted in sandbox mode
+	sandboxFunc func(c ColorValues) error
+
+	saveFlex  *cview.Flex
+	saveTitle *cview.TextView
+	saveInput *cview.InputField
+	// The color that is being named
+	saveColor color.HSV
+	// The page and primitive to return to when leaving the save page
+	saveReturnPage string
+	saveFocus      cview.Primitive
+
+	// Index of the color blindness type being simulated
+	simulation int
+
+	sliderFlex  *cview.Flex
+	sliderForm  *cview.Form
+	sliderBlock *cview.TextView
+	sliderText  *cview.TextView
+	hSlider     *cview.Slider
+	sSlider     *cview.Slider
+	vSlider     *cview.Slider
+	// The page and primitive to return to when leaving the slider page
+	sliderReturnPage string
+	sliderFocus      cview.Primitive
+
+	// Holds the pages and the status line below them, which shows copy
+	// confirmations, save results, and errors on every page
+	statusFlex *cview.Flex
+	statusText *cview.TextView
+	// Counts the status messages, so that an older message doesn't clear a newer one
+	statusID int
+
+	// The theme of the current run
+	theme string
+
+	// The error from caching the colors of the color files of the current run
+	colorCacheErr error
+
+	undoStack        []selection
+	redoStack        []selection
+	currentSelection selection
+	hasSelection     bool
+	// True while a selection is being restored, so that it is not recorded again
+	restoring bool
+}
+
+// NewPicker function creates a picker that starts with the given
+// configuration
+func NewPicker(config Config) *Picker {
+	return &Picker{config: config}
+}
+
+// Start method starts the picker and returns the selected color. If the
+// picker is quit without a color being selected, ErrCanceled is returned. A
+// picker can't be started again until Start returns.
+func (p *Picker) Start() (ColorValues, error) {
+	return p.start(1)
+}
+
+// Reset everything that is left over from an earlier run, so that every run
+// starts like the first one. The primitives are created again with the styles
+// of the theme, so the theme is set first and kept.
+func (p *Picker) resetState() {
+	hTable := cview.NewTable()
+
+	*p = Picker{
+		config: p.config,
+		theme:  p.theme,
+
+		app:            cview.NewApplication(),
+		pages:          cview.NewPages(),
+		hFlex:          cview.NewFlex(),
+		svFlex:         cview.NewFlex(),
+		hTable:         hTable,
+		svTable:        cview.NewTable(),
+		darkHBlock:     cview.NewTextView(),
+		darkHText:      cview.NewTextView(),
+		lightHBlock:    cview.NewTextView(),
+		lightHText:     cview.NewTextView(),
+		darkSVBlock:    cview.NewTextView(),
+		darkSVText:     cview.NewTextView(),
+		lightSVBlock:   cview.NewTextView(),
+		lightSVText:    cview.NewTextView(),
+		colorPageTitle: cview.NewTextView(),
+		jsonColors:     cview.NewFlex(),
+		colorPages:     cview.NewPages(),
+		helpFlex:       cview.NewFlex(),
+		helpView:       cview.NewTextView(),
+		helpFocus:      hTable,
+		searchFlex:     cview.NewFlex(),
+		searchStatus:   cview.NewTextView(),
+		searchInput:    cview.NewInputField(),
+		hFocus:         hTable,
+		hHelpText:      cview.NewTextView(),
+		alpha:          1.0,
+		svStep:         DEFAULT_SV_STEP,
+		svReadout:      cview.NewTextView(),
+
+		clipboardType: "hex",
+
+		cmykFlex:   cview.NewFlex(),
+		cmykForm:   cview.NewForm(),
+		cmykBlock:  cview.NewTextView(),
+		cmykText:   cview.NewTextView(),
+		cmykSwatch: cview.NewTextView(),
+		cSlider:    cview.NewSlider(),
+		mSlider:    cview.NewSlider(),
+		ySlider:    cview.NewSlider(),
+		kSlider:    cview.NewSlider(),
+		cmykFocus:  hTable,
+
+		truecolorTerminal: true,
+
+		contrastText: cview.NewTextView(),
+		bestText:     cview.NewTextView(),
+		sampleText:   cview.NewTextView(),
+
+		favoritesFlex:  cview.NewFlex(),
+		favoritesTable: cview.NewTable(),
+		favoritesFocus: hTable,
+
+		filterInput: cview.NewInputField(),
+
+		gradientFlex:  cview.NewFlex(),
+		gradientTitle: cview.NewTextView(),
+		gradientTable: cview.NewTable(),
+		gradientText:  cview.NewTextView(),
+		gradientSteps: GRADIENT_STEPS,
+		gradientFocus: hTable,
+
+		harmonyFlex:  cview.NewFlex(),
+		harmonyTitle: cview.NewTextView(),
+		harmonyTable: cview.NewTable(),
+		harmonyFocus: hTable,
+
+		hexInput: cview.NewInputField(),
+
+		historyTable: cview.NewTable(),
+
+		hueInput: cview.NewInputField(),
+
+		keybindings: defaultKeybindings,
+
+		hueStep: 2,
+		svRows:  DEFAULT_SV_ROWS,
+
+		pickCount: 1,
+
+		namedFlex:  cview.NewFlex(),
+		namedList:  cview.NewList(),
+		namedFocus: hTable,
+
+		nearestText: cview.NewTextView(),
+
+		palette16Flex:  cview.NewFlex(),
+		palette16Table: cview.NewTable(),
+		palette16Text:  cview.NewTextView(),
+		palette16Focus: hTable,
+
+		palette256Flex:  cview.NewFlex(),
+		palette256Table: cview.NewTable(),
+		palette256Text:  cview.NewTextView(),
+		palette256Focus: hTable,
+
+		rampTable: cview.NewTable(),
+
+		errorModal: cview.NewModal(),
+
+		saveFlex:  cview.NewFlex(),
+		saveTitle: cview.NewTextView(),
+		saveInput: cview.NewInputField(),
+		saveFocus: hTable,
+
+		sliderFlex:  cview.NewFlex(),
+		sliderForm:  cview.NewForm(),
+		sliderBlock: cview.NewTextView(),
+		sliderText:  cview.NewTextView(),
+		hSlider:     cview.NewSlider(),
+		sSlider:     cview.NewSlider(),
+		vSlider:     cview.NewSlider(),
+		sliderFocus: hTable,
+
+		statusFlex: cview.NewFlex(),
+		statusText: cview.NewTextView(),
+	}
+}
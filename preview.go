@@ -0,0 +1,49 @@
+package cpick
+
+import (
+	"github.com/ethanbaker/cpick/cview"
+)
+
+var colorBlockLarge string = `
+  ██████████████████████████████
+  ██████████████████████████████
+  ██████████████████████████████
+  ██████████████████████████████
+  ██████████████████████████████
+  ██████████████████████████████
+  ██████████████████████████████
+  ██████████████████████████████
+`
+
+// previewBlock type used to hold a color block and the flex it is in
+type previewBlock struct {
+	flex  *cview.Flex
+	block *cview.TextView
+}
+
+// Turn the large preview on or off. The preview is only resized on screens
+// that show the wide color blocks.
+func (p *Picker) setLargePreview(on bool) {
+	p.largePreview = on
+	if p.smallWidth || p.smallHeight {
+		return
+	}
+
+	block, size := colorBlockWide, 2
+	tableSize, presetSize := 4, 9
+	if p.largePreview {
+		block, size = colorBlockLarge, 5
+		tableSize, presetSize = 3, 7
+	}
+
+	for _, b := range p.previewBlocks {
+		b.block.SetText(block)
+		b.flex.ResizeItem(b.block, 0, size)
+	}
+
+	// Give the color panels more room so the color values still fit
+	p.svFlex.ResizeItem(p.svTable, 0, tableSize)
+	if p.hLowerFlex != nil {
+		p.hLowerFlex.ResizeItem(p.jsonColors, 0, presetSize)
+	}
+}
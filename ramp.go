@@ -0,0 +1,98 @@
+package cpick
+
+import (
+	"fmt"
+
+	color "github.com/ethanbaker/colors"
+	"github.com/ethanbaker/cpick/cview"
+	"github.com/gdamore/tcell/v2"
+)
+
+// Number of shades and tints generated for the selected color
+const RAMP_SIZE = 9
+
+// Ramp Handlers ----------------------------------------------------------
+
+func (p *Picker) rampDoneFunc(key tcell.Key) {
+	switch {
+	case p.keyMatches("quit", key, 0):
+		p.app.Stop()
+	case p.keyMatches("switch-table", key, 0):
+		p.toggleRamp()
+	}
+}
+
+func (p *Picker) rampSelectedFunc(row int, column int) {
+	hsv, ok := p.rampTable.GetCell(row, column).GetReference().(color.HSV)
+	if !ok {
+		return
+	}
+
+	p.selectColor(hsv, hsv)
+}
+
+// Switch between the saturation-value table and the shades and tints
+func (p *Picker) toggleRamp() {
+	if p.rampTable.HasFocus() {
+		p.app.SetFocus(p.svTable)
+		p.rampTable.SetSelectable(false, false)
+	} else if p.svTable.HasFocus() && p.showRamp {
+		p.app.SetFocus(p.rampTable)
+		p.rampTable.SetSelectable(true, true)
+	}
+}
+
+// Ramp setup -------------------------------------------------------------
+
+func (p *Picker) rampSetup() {
+	p.rampTable.SetSelectable(false, false)
+	p.rampTable.SetScrollBarVisibility(cview.ScrollBarNever)
+	p.rampTable.SetSelectedStyle(tcell.ColorDefault, tcell.ColorGray, tcell.AttrNone)
+	p.rampTable.SetDoneFunc(p.rampDoneFunc)
+	p.rampTable.SetSelectedFunc(p.rampSelectedFunc)
+
+	// The ramp is otherwise only drawn once the selection of the
+	// saturation-value table changes
+	row, column := p.svTable.GetSelection()
+	p.drawRampTable(p.svColor(column, p.svValue(row*2+p.svHalf)))
+}
+
+// Fill the ramp table with evenly spaced shades (lower values) and tints
+// (lower saturations) of a color
+func (p *Picker) drawRampTable(hsv color.HSV) {
+	p.rampTable.Clear()
+
+	for i, title := range [...]string{"Shades", "Tints"} {
+		cell := cview.NewTableCell("  " + title)
+		cell.SetSelectable(false)
+		p.rampTable.SetCell(0, i, cell)
+	}
+
+	for i := 1; i <= RAMP_SIZE; i++ {
+		shade := hsv
+		shade.V = hsv.V * (RAMP_SIZE + 1 - i) / (RAMP_SIZE + 1)
+
+		tint := hsv
+		tint.S = hsv.S * (RAMP_SIZE + 1 - i) / (RAMP_SIZE + 1)
+
+		for column, h := range [...]color.HSV{shade, tint} {
+			hex := color.HSVtoHex(h)
+			rgb := color.HSVtoRGB(h)
+
+			text := fmt.Sprintf("  ████ %v", "#"+hex)
+			if tag := p.readableTag(rgb); tag != "" {
+				text = fmt.Sprintf("  ████ %v#%v", tag, hex)
+			}
+
+			cell := cview.NewTableCell(text)
+			cell.SetTextColor(p.displayColor(rgb))
+			cell.SetReference(h)
+			p.rampTable.SetCell(i, column, cell)
+		}
+	}
+
+	row, _ := p.rampTable.GetSelection()
+	if row == 0 {
+		p.rampTable.Select(1, 0)
+	}
+}
@@ -0,0 +1,29 @@
+package cpick
+
+import (
+	"math/rand"
+
+	color "github.com/ethanbaker/colors"
+)
+
+// Random functions -------------------------------------------------------
+
+// Random returns the color values of a random color without starting the
+// application. The color is named from the preset colors the same way as in
+// the application.
+func Random() ColorValues {
+	hsv := randomHSV()
+	return newColorValues(hsv, colorName(filePresetColors(""), hsv, hsv), 1)
+}
+
+// Move to a random cell of the saturation-value table of a random hue
+func (p *Picker) randomizeColor() {
+	p.selectSVColor(randomHSV())
+}
+
+// Get a random color with any hue, saturation, and value. The top-level
+// functions of math/rand are seeded randomly and safe to use from several
+// goroutines, so Random can be called at the same time as the application.
+func randomHSV() color.HSV {
+	return color.HSV{H: rand.Intn(360), S: rand.Intn(101), V: rand.Intn(101)}
+}
@@ -0,0 +1,69 @@
+package cpick
+
+import (
+	"fmt"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// Reload Handlers --------------------------------------------------------
+
+func (p *Picker) errorModalDoneFunc(buttonIndex int, buttonLabel string) {
+	p.pages.RemovePage("Error page")
+	p.app.SetFocus(p.errorFocus)
+}
+
+// Show an error over the current page
+func (p *Picker) showError(text string) {
+	p.errorFocus = p.app.GetFocus()
+
+	p.errorModal.SetText(text)
+	p.pages.AddPage("Error page", p.errorModal, false, true)
+	p.app.SetFocus(p.errorModal)
+}
+
+// Read the color file again and rebuild the preset color pages
+func (p *Picker) reloadColors() {
+	data, err := getCustomColors(p.palette)
+	if err != nil {
+		p.showError(fmt.Sprintf("Could not reload the colors:\n%v", err))
+		return
+	}
+
+	// The colors are still used to name colors when there are no preset
+	// color pages to rebuild
+	p.fileColors = listColors(data)
+	if p.noPresets {
+		return
+	}
+
+	focused := p.colorPages.HasFocus()
+	index := p.colorPageIndex
+
+	p.drawColorPages(data)
+	p.setSearchNames()
+	p.searchIndexes = nil
+
+	// Keep the same page if it still exists
+	if index < len(p.colorInfo) {
+		p.colorPageIndex = index
+		p.showColorPage(index)
+		p.setColorPageTitle()
+	}
+
+	if focused {
+		p.app.SetFocus(p.colorPages)
+		p.colorInfo[p.colorPageIndex].table.SetSelectedStyle(tcell.ColorDefault, tcell.ColorGray, tcell.AttrNone)
+		p.colorPageSelectionChangedFunc(p.colorInfo[p.colorPageIndex].table.GetSelection())
+	}
+}
+
+// Reload setup -----------------------------------------------------------
+
+func (p *Picker) errorModalSetup() {
+	p.pages.RemovePage("Error page")
+
+	p.errorModal.ClearButtons()
+	p.errorModal.AddButtons([]string{"OK"})
+	p.errorModal.SetDoneFunc(p.errorModalDoneFunc)
+}
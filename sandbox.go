@@ -0,0 +1,21 @@
+package cpick
+
+import "fmt"
+
+// Sandbox functions ------------------------------------------------------
+
+// Note a color selected in sandbox mode instead of returning it. The color is
+// added to the recently selected colors, passed to the sandbox function, and
+// shown below the page.
+func (p *Picker) noteSandboxColor(c ColorValues) {
+	p.addHistory(c.Hex)
+
+	if p.sandboxFunc != nil {
+		if err := p.sandboxFunc(c); err != nil {
+			p.setStatus(fmt.Sprintf("Could not note #%v: %v", c.Hex, err), true)
+			return
+		}
+	}
+
+	p.setStatus(fmt.Sprintf("Noted #%v (%v)", c.Hex, c.Name), false)
+}
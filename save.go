@@ -0,0 +1,169 @@
+package cpick
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	color "github.com/ethanbaker/colors"
+	"github.com/ethanbaker/cpick/cview"
+	"github.com/gdamore/tcell/v2"
+)
+
+// The name of the color list in colors.json that saved colors are added to
+const SAVED_COLOR_LIST = "custom"
+
+// Save Handlers ----------------------------------------------------------
+
+func (p *Picker) saveInputDoneFunc(key tcell.Key) {
+	switch key {
+	case tcell.KeyEscape:
+		p.hideSave()
+
+	case tcell.KeyEnter:
+		name := strings.TrimSpace(p.saveInput.GetText())
+		if name == "" {
+			p.saveInput.SetFieldNote("Please enter a name")
+			return
+		}
+
+		p.hideSave()
+		p.saveCurrentColor(name)
+	}
+}
+
+// Show the input for the name of the highlighted color
+func (p *Picker) showSave() {
+	hsv, ok := p.getCurrentColor()
+	if !ok {
+		return
+	}
+	p.saveColor = hsv
+
+	p.saveReturnPage, _ = p.pages.GetFrontPage()
+	p.saveFocus = p.app.GetFocus()
+
+	hex := strings.ToLower(string(color.HSVtoHex(hsv)))
+	p.saveTitle.SetText(fmt.Sprintf("Save [#%v]██████[-] #%v to the %q colors of colors.json", hex, hex, SAVED_COLOR_LIST))
+	p.saveInput.SetText("")
+	p.saveInput.ResetFieldNote()
+
+	p.pages.SwitchToPage("Save page")
+	p.app.SetFocus(p.saveInput)
+}
+
+func (p *Picker) hideSave() {
+	p.pages.SwitchToPage(p.saveReturnPage)
+	p.app.SetFocus(p.saveFocus)
+}
+
+// Save setup -------------------------------------------------------------
+
+func (p *Picker) saveSetup() {
+	p.saveTitle.SetTextAlign(cview.AlignCenter)
+	p.saveTitle.SetDynamicColors(true)
+
+	p.saveInput.SetLabel("Name: ")
+	p.saveInput.SetFieldNoteTextColor(tcell.ColorRed)
+	p.saveInput.SetDoneFunc(p.saveInputDoneFunc)
+
+	help := cview.NewTextView()
+	help.SetTextAlign(cview.AlignRight)
+	help.SetText("Type a name and press enter to save the color, or press escape to go back")
+
+	p.saveFlex.SetDirection(cview.FlexRow)
+	p.saveFlex.AddItem(p.saveTitle, 1, 0, false)
+	p.saveFlex.AddItem(nil, 1, 0, false)
+	p.saveFlex.AddItem(p.saveInput, 2, 0, true)
+	p.saveFlex.AddItem(nil, 0, 1, false)
+	p.saveFlex.AddItem(help, 1, 0, false)
+}
+
+// Helper functions -------------------------------------------------------
+
+// Save the color that is being named to colors.json and rebuild the preset
+// color pages so that it shows up right away
+func (p *Picker) saveCurrentColor(name string) {
+	hex := "#" + strings.ToLower(string(color.HSVtoHex(p.saveColor)))
+
+	path, err := saveCustomColor(name, hex)
+	if err != nil {
+		p.setStatus(fmt.Sprintf("Could not save %v: %v", name, err), true)
+		return
+	}
+
+	p.reloadColors()
+	p.setStatus(fmt.Sprintf("Saved %v (%v) to %v", name, hex, path), false)
+}
+
+// Add a named color to the saved color list of colors.json and return the
+// path of the file. If there is no colors.json, one is created in
+// ~/.config/cpick with the preset colors, so that they are still shown.
+func saveCustomColor(name string, hex string) (string, error) {
+	path, err := getPath()
+	if err != nil {
+		return "", err
+	}
+
+	if path == "" {
+		if path, err = configPath("colors.json"); err != nil {
+			return "", err
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return "", err
+		}
+	}
+
+	return path, writeCustomColor(path, jsonColor{NAME: name, VALUE: hex})
+}
+
+// Add a color to the saved color list of a color file and write the file back
+func writeCustomColor(path string, c jsonColor) error {
+	data, err := readColorFile(path)
+	if err != nil {
+		return err
+	}
+	data = addCustomColor(data, c)
+
+	raw, err := json.MarshalIndent(data, "", "    ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, append(raw, '\n'), 0644)
+}
+
+// Read the color data of a color file, or the preset colors if the file
+// doesn't exist yet
+func readColorFile(path string) (jsonData, error) {
+	var data jsonData
+
+	raw, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		raw = []byte(presetData)
+	} else if err != nil {
+		return data, err
+	}
+
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return data, fmt.Errorf("%v is not a valid color file: %v", path, err)
+	}
+
+	return data, nil
+}
+
+// Add a color to the saved color list, which is added after the other lists
+// if it doesn't exist yet
+func addCustomColor(data jsonData, c jsonColor) jsonData {
+	for i := range data.COLORLIST {
+		if data.COLORLIST[i].NAME == SAVED_COLOR_LIST {
+			data.COLORLIST[i].COLORS = append(data.COLORLIST[i].COLORS, c)
+			return data
+		}
+	}
+
+	data.COLORLIST = append(data.COLORLIST, jsonColorType{NAME: SAVED_COLOR_LIST, COLORS: []jsonColor{c}})
+	return data
+}
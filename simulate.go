@@ -0,0 +1,85 @@
+package cpick
+
+import (
+	"math"
+
+	color "github.com/ethanbaker/colors"
+	"github.com/gdamore/tcell/v2"
+)
+
+// Names of the color blindness types that can be simulated. The first
+// name is used when no simulation is active.
+var simulationNames = [...]string{"", "protanopia", "deuteranopia", "tritanopia"}
+
+// Matrices used to approximate how each type of color blindness sees RGB
+// values
+var simulationMatrices = [...][3][3]float64{
+	{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}},
+	{{0.567, 0.433, 0}, {0.558, 0.442, 0}, {0, 0.242, 0.758}},
+	{{0.625, 0.375, 0}, {0.7, 0.3, 0}, {0, 0.3, 0.7}},
+	{{0.95, 0.05, 0}, {0, 0.433, 0.567}, {0, 0.475, 0.525}},
+}
+
+// Get the index of a simulation by name. Unknown names turn the simulation
+// off.
+func getSimulation(name string) int {
+	for i, v := range simulationNames {
+		if v == name {
+			return i
+		}
+	}
+
+	return 0
+}
+
+// Cycle through the color blindness simulations and redraw the tables
+func (p *Picker) toggleSimulation() {
+	p.simulation = (p.simulation + 1) % len(simulationNames)
+
+	p.setSimulationText()
+	p.redrawColors()
+}
+
+// Redraw the tables and the color blocks of the focused table after the way
+// colors are drawn changes
+func (p *Picker) redrawColors() {
+	p.drawHTable()
+	p.drawSVTable()
+	p.drawHistoryTable()
+
+	switch {
+	case p.svTable.HasFocus():
+		p.svTableSelectionChangedFunc(p.svTable.GetSelection())
+	case p.hTable.HasFocus():
+		p.hTableSelectionChangedFunc(p.hTable.GetSelection())
+	case p.colorPages.HasFocus():
+		p.colorPageSelectionChangedFunc(p.colorInfo[p.colorPageIndex].table.GetSelection())
+	}
+}
+
+func (p *Picker) setSimulationText() {
+	if p.simulation == 0 {
+		p.hHelpText.SetText(p.helpText("Press {help} to see help"))
+	} else {
+		p.hHelpText.SetText("Simulating " + simulationNames[p.simulation] + p.helpText("    Press {help} to see help"))
+	}
+}
+
+// Apply the color blindness simulation to an RGB value
+func (p *Picker) simulateRGB(rgb color.RGB) color.RGB {
+	m := simulationMatrices[p.simulation]
+	values := [3]float64{float64(rgb.R), float64(rgb.G), float64(rgb.B)}
+
+	var result [3]int
+	for i := 0; i < 3; i++ {
+		v := m[i][0]*values[0] + m[i][1]*values[1] + m[i][2]*values[2]
+		result[i] = int(math.Min(255, math.Max(0, math.Round(v))))
+	}
+
+	return color.RGB{R: result[0], G: result[1], B: result[2]}
+}
+
+// Get the tcell color that is drawn on the screen for an RGB value
+func (p *Picker) displayColor(rgb color.RGB) tcell.Color {
+	return p.renderColor(p.simulateRGB(rgb))
+}
@@ -0,0 +1,122 @@
+package cpick
+
+import (
+	color "github.com/ethanbaker/colors"
+	"github.com/ethanbaker/cpick/cview"
+	"github.com/gdamore/tcell/v2"
+)
+
+// Slider Handlers --------------------------------------------------------
+
+func (p *Picker) sliderChangedFunc(value int) {
+	hsv := p.sliderColor()
+	p.setColorValues(hsv, p.sliderBlock, p.sliderText, hsv, p.sliderBlock, p.sliderText)
+}
+
+// Select the color of the sliders when enter is pressed on one of them
+func (p *Picker) sliderInputCapture(event *tcell.EventKey) *tcell.EventKey {
+	if event.Key() == tcell.KeyEnter {
+		p.selectSliderColor()
+		return nil
+	}
+
+	return event
+}
+
+// Show the sliders at the highlighted color, or go back if they are already
+// shown
+func (p *Picker) toggleSliders() {
+	if p.sliderForm.HasFocus() {
+		p.hideSliders()
+		return
+	}
+
+	if hsv, ok := p.getCurrentColor(); ok {
+		p.setSliderColor(hsv)
+	}
+
+	p.sliderReturnPage, _ = p.pages.GetFrontPage()
+	p.sliderFocus = p.app.GetFocus()
+
+	p.pages.SwitchToPage("Slider page")
+	p.sliderForm.SetFocus(0)
+	p.app.SetFocus(p.sliderForm)
+}
+
+func (p *Picker) hideSliders() {
+	p.pages.SwitchToPage(p.sliderReturnPage)
+	p.app.SetFocus(p.sliderFocus)
+}
+
+// Slider setup -----------------------------------------------------------
+
+func (p *Picker) sliderSetup() {
+	for _, s := range []*cview.Slider{p.hSlider, p.sSlider, p.vSlider} {
+		s.SetIncrement(1)
+		s.SetChangedFunc(p.sliderChangedFunc)
+		s.SetInputCapture(p.sliderInputCapture)
+	}
+
+	p.hSlider.SetLabel("Hue (0-359)")
+	p.hSlider.SetMax(359)
+	p.sSlider.SetLabel("Saturation (0-100)")
+	p.sSlider.SetMax(100)
+	p.vSlider.SetLabel("Value (0-100)")
+	p.vSlider.SetMax(100)
+
+	// The setup runs every time the application starts, so remove the
+	// sliders that were already added
+	p.sliderForm.Clear(true)
+	p.sliderForm.AddFormItem(p.hSlider)
+	p.sliderForm.AddFormItem(p.sSlider)
+	p.sliderForm.AddFormItem(p.vSlider)
+	p.sliderForm.AddButton("Select", p.selectSliderColor)
+	p.sliderForm.SetCancelFunc(p.hideSliders)
+
+	p.sliderBlock.SetText(colorBlockWide)
+	p.sliderBlock.SetScrollBarVisibility(cview.ScrollBarNever)
+	p.sliderText.SetScrollBarVisibility(cview.ScrollBarNever)
+	p.sliderText.SetDynamicColors(true)
+
+	title := cview.NewTextView()
+	title.SetTextAlign(cview.AlignCenter)
+	title.SetText("Adjust the hue, saturation, and value of the color")
+
+	help := cview.NewTextView()
+	help.SetTextAlign(cview.AlignRight)
+	help.SetText("Press h/l to move a slider, tab to switch sliders, enter to select the color, and S or escape to go back")
+
+	colorFlex := cview.NewFlex()
+	colorFlex.SetDirection(cview.FlexColumn)
+	colorFlex.AddItem(p.sliderBlock, 0, 1, false)
+	colorFlex.AddItem(p.sliderText, 0, 2, false)
+
+	p.sliderFlex.SetDirection(cview.FlexRow)
+	p.sliderFlex.AddItem(title, 1, 0, false)
+	p.sliderFlex.AddItem(p.sliderForm, 9, 0, true)
+	p.sliderFlex.AddItem(colorFlex, 0, 1, false)
+	p.sliderFlex.AddItem(help, 1, 0, false)
+
+	p.setSliderColor(color.HSV{H: 0, S: 100, V: 100})
+}
+
+// Helper functions -------------------------------------------------------
+
+// Get the color of the sliders
+func (p *Picker) sliderColor() color.HSV {
+	return color.HSV{H: p.hSlider.GetProgress(), S: p.sSlider.GetProgress(), V: p.vSlider.GetProgress()}
+}
+
+// Move the sliders to the given color
+func (p *Picker) setSliderColor(hsv color.HSV) {
+	p.hSlider.SetProgress(hsv.H)
+	p.sSlider.SetProgress(hsv.S)
+	p.vSlider.SetProgress(hsv.V)
+	p.sliderChangedFunc(0)
+}
+
+// Select the color of the sliders as the final color
+func (p *Picker) selectSliderColor() {
+	hsv := p.sliderColor()
+	p.selectColor(hsv, hsv)
+}
@@ -0,0 +1,44 @@
+package cpick
+
+import (
+	"sort"
+	"strings"
+
+	color "github.com/ethanbaker/colors"
+)
+
+// Ways that the preset color pages can be sorted
+var sortModes = [...]string{"default", "hue", "lightness", "name"}
+
+// Sort the given colors with a sort mode. The colors are copied, so the
+// original order is kept.
+func sortColors(colors []jsonColor, mode int) []jsonColor {
+	sorted := append([]jsonColor(nil), colors...)
+
+	switch sortModes[mode] {
+	case "hue":
+		sort.SliceStable(sorted, func(i, j int) bool {
+			return sorted[i].HSV.H < sorted[j].HSV.H
+		})
+	case "lightness":
+		sort.SliceStable(sorted, func(i, j int) bool {
+			return color.RGBtoHSL(sorted[i].RGB).L < color.RGBtoHSL(sorted[j].RGB).L
+		})
+	case "name":
+		sort.SliceStable(sorted, func(i, j int) bool {
+			return strings.ToLower(sorted[i].NAME) < strings.ToLower(sorted[j].NAME)
+		})
+	}
+
+	return sorted
+}
+
+// Switch the current preset color page to the next sort mode
+func (p *Picker) cycleSort() {
+	p.colorInfo[p.colorPageIndex].sort = (p.colorInfo[p.colorPageIndex].sort + 1) % len(sortModes)
+	p.drawColorTable(p.colorPageIndex)
+	p.setColorPageTitle()
+	p.searchIndexes = nil
+
+	p.colorInfo[p.colorPageIndex].table.Select(0, 0)
+}
@@ -0,0 +1,49 @@
+package cpick
+
+import (
+	"time"
+
+	"github.com/ethanbaker/cpick/cview"
+)
+
+// How long a status message is shown before it is cleared
+const STATUS_DURATION = 3 * time.Second
+
+// Status functions -------------------------------------------------------
+
+// Show a message on the status line for a short time. Errors are shown in red.
+func (p *Picker) setStatus(msg string, isError bool) {
+	p.statusID++
+
+	text := cview.Escape(msg)
+	if isError {
+		text = "[red]" + text + "[-]"
+	}
+	p.statusText.SetText(text)
+
+	id, a := p.statusID, p.app
+	go func() {
+		time.Sleep(STATUS_DURATION)
+		a.QueueUpdateDraw(func() {
+			if p.statusID == id {
+				p.clearStatus()
+			}
+		})
+	}()
+}
+
+func (p *Picker) clearStatus() {
+	p.statusText.SetText("")
+}
+
+// Status setup -----------------------------------------------------------
+
+func (p *Picker) statusSetup() {
+	p.statusText.SetDynamicColors(true)
+	p.statusText.SetScrollBarVisibility(cview.ScrollBarNever)
+	p.statusText.SetText("")
+
+	p.statusFlex.SetDirection(cview.FlexRow)
+	p.statusFlex.AddItem(p.pages, 0, 1, true)
+	p.statusFlex.AddItem(p.statusText, 1, 0, false)
+}
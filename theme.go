@@ -0,0 +1,96 @@
+package cpick
+
+import (
+	"sync"
+
+	color "github.com/ethanbaker/colors"
+	"github.com/ethanbaker/cpick/cview"
+	"github.com/gdamore/tcell/v2"
+)
+
+// The styles of cview before any theme was set, which are used by the dark
+// theme
+var darkStyles = cview.Styles
+
+// cview gives primitives the styles in its package-level Styles when they are
+// created, so pickers set their theme and create their primitives one at a
+// time
+var themeMutex sync.Mutex
+
+// Theme functions --------------------------------------------------------
+
+// Get the styles of a theme, or false if there is no theme with the name
+func themeStyles(name string) (cview.Theme, bool) {
+	styles := darkStyles
+
+	switch name {
+	case "", "dark":
+		return styles, true
+
+	// Dark text on a white background
+	case "light":
+		styles.TitleColor = tcell.ColorBlack.TrueColor()
+		styles.BorderColor = tcell.ColorBlack.TrueColor()
+		styles.GraphicsColor = tcell.ColorBlack.TrueColor()
+
+		styles.PrimaryTextColor = tcell.ColorBlack.TrueColor()
+		styles.SecondaryTextColor = tcell.ColorNavy.TrueColor()
+		styles.TertiaryTextColor = tcell.ColorDarkGreen.TrueColor()
+		styles.InverseTextColor = tcell.ColorWhite.TrueColor()
+		styles.ContrastPrimaryTextColor = tcell.ColorBlack.TrueColor()
+		styles.ContrastSecondaryTextColor = tcell.ColorDimGray.TrueColor()
+
+		styles.PrimitiveBackgroundColor = tcell.ColorWhite.TrueColor()
+		styles.ContrastBackgroundColor = tcell.ColorLightGray.TrueColor()
+		styles.MoreContrastBackgroundColor = tcell.ColorDarkGray.TrueColor()
+
+		styles.ScrollBarColor = tcell.ColorGray.TrueColor()
+		return styles, true
+
+	// Only black, white, and grays, so that the colors being picked are the
+	// only colors on the screen
+	case "mono":
+		styles.SecondaryTextColor = tcell.ColorWhite.TrueColor()
+		styles.TertiaryTextColor = tcell.ColorSilver.TrueColor()
+		styles.InverseTextColor = tcell.ColorBlack.TrueColor()
+		styles.ContrastPrimaryTextColor = tcell.ColorBlack.TrueColor()
+		styles.ContrastSecondaryTextColor = tcell.ColorSilver.TrueColor()
+
+		styles.ContrastBackgroundColor = tcell.ColorDimGray.TrueColor()
+		styles.MoreContrastBackgroundColor = tcell.ColorSilver.TrueColor()
+		return styles, true
+	}
+
+	return cview.Theme{}, false
+}
+
+// Set the styles of a theme, which are used by the primitives created after
+// it. An invalid theme name uses the dark theme.
+func (p *Picker) setTheme(name string) {
+	styles, ok := themeStyles(name)
+	if !ok || name == "" {
+		name, styles = "dark", darkStyles
+	}
+
+	p.theme = name
+	cview.Styles = styles
+}
+
+// Helper functions -------------------------------------------------------
+
+// Get the color tag that keeps the text next to a color readable when the
+// color is too close to the background of the theme, or an empty string if
+// the text can be drawn in the color itself
+func (p *Picker) readableTag(rgb color.RGB) string {
+	if p.theme == "light" {
+		if rgb.R+rgb.G+rgb.B >= 3*255-84 {
+			return "[black]"
+		}
+		return ""
+	}
+
+	if rgb.R+rgb.G+rgb.B <= 84 {
+		return "[white]"
+	}
+	return ""
+}
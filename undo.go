@@ -0,0 +1,86 @@
+package cpick
+
+// Maximum number of selections that can be undone
+const UNDO_SIZE = 50
+
+// selection holds the hue and the selected cell (and half of the cell) of the
+// saturation-value table
+type selection struct {
+	hue    int
+	row    int
+	column int
+	half   int
+}
+
+// Record a new selection of the saturation-value table
+func (p *Picker) recordSelection(row int, column int) {
+	if p.restoring {
+		return
+	}
+
+	s := selection{p.hue, row, column, p.svHalf}
+	if p.hasSelection && s == p.currentSelection {
+		return
+	}
+
+	if p.hasSelection {
+		p.undoStack = append(p.undoStack, p.currentSelection)
+		if len(p.undoStack) > UNDO_SIZE {
+			p.undoStack = p.undoStack[len(p.undoStack)-UNDO_SIZE:]
+		}
+	}
+
+	p.currentSelection = s
+	p.hasSelection = true
+	p.redoStack = nil
+}
+
+// Go back to the previous selection
+func (p *Picker) undoSelection() {
+	if len(p.undoStack) == 0 {
+		return
+	}
+
+	p.redoStack = append(p.redoStack, p.currentSelection)
+	p.currentSelection = p.undoStack[len(p.undoStack)-1]
+	p.undoStack = p.undoStack[:len(p.undoStack)-1]
+
+	p.restoreSelection(p.currentSelection)
+}
+
+// Go forward to a selection that was undone
+func (p *Picker) redoSelection() {
+	if len(p.redoStack) == 0 {
+		return
+	}
+
+	p.undoStack = append(p.undoStack, p.currentSelection)
+	p.currentSelection = p.redoStack[len(p.redoStack)-1]
+	p.redoStack = p.redoStack[:len(p.redoStack)-1]
+
+	p.restoreSelection(p.currentSelection)
+}
+
+func (p *Picker) restoreSelection(s selection) {
+	p.restoring = true
+	defer func() { p.restoring = false }()
+
+	p.pages.SwitchToPage("Saturation-Value page")
+	p.app.SetFocus(p.svTable)
+
+	if s.hue != p.hue {
+		p.hue = s.hue
+		p.setSVCursor()
+		p.drawSVTable()
+	}
+
+	p.svHalf = s.half
+	p.svTable.Select(s.row, s.column)
+}
+
+// Remove every recorded selection
+func (p *Picker) clearSelections() {
+	p.undoStack = nil
+	p.redoStack = nil
+	p.hasSelection = false
+}